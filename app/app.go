@@ -268,7 +268,7 @@ func NewApp(
 		minttypes.StoreKey,
 		// this line is used by starport scaffolding # stargate/app/storeKey
 	)
-	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey)
+	tkeys := sdk.NewTransientStoreKeys(paramstypes.TStoreKey, slashingtypes.TStoreKey)
 	memKeys := sdk.NewMemoryStoreKeys(capabilitytypes.MemStoreKey)
 
 	app := &App{
@@ -324,7 +324,7 @@ func NewApp(
 		app.MintKeeper,
 	)
 	app.SlashingKeeper = slashingkeeper.NewKeeper(
-		appCodec, keys[slashingtypes.StoreKey], srstakingKeeper, app.GetSubspace(slashingtypes.ModuleName),
+		appCodec, keys[slashingtypes.StoreKey], tkeys[slashingtypes.TStoreKey], srstakingKeeper, app.GetSubspace(slashingtypes.ModuleName),
 	)
 	// register the srStaking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
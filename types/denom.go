@@ -1,118 +1,1661 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/cosmos/cosmos-sdk/types"
 )
 
-const maxDecBitLen = 315
+const maxDecBitLen = 315
+
+// maxParsedCoins bounds the number of coins ParseCoinsNormalized accepts.
+const maxParsedCoins = 64
+
+// denomChangeBuffer is the channel capacity of each SubscribeDenomChanges
+// subscriber.
+const denomChangeBuffer = 16
+
+// DenomChangeKind is the kind of registry change a DenomChangeEvent reports.
+type DenomChangeKind string
+
+const (
+	DenomRegistered   DenomChangeKind = "register"
+	DenomDeregistered DenomChangeKind = "deregister"
+	DenomDeprecated   DenomChangeKind = "deprecate"
+	DenomUpdated      DenomChangeKind = "update"
+)
+
+// DenomChangeEvent reports a change of the registry entry of Denom.
+type DenomChangeEvent struct {
+	Kind  DenomChangeKind
+	Denom string
+}
+
+// denomPair is a source and destination denom of a conversion.
+type denomPair struct {
+	src, dst string
+}
+
+// exponentDelta is the memoized ratio of the units of a denomPair. When the
+// ratio is a power of ten, clean is set, delta is its exponent and scale is
+// 10^|delta|.
+type exponentDelta struct {
+	delta int
+	scale types.Int
+	clean bool
+}
+
+// Registry is a set of registered denominations along with their units, and
+// the conversions between them. The package level functions operate on a
+// default registry, independent registries are created with NewRegistry, e.g.
+// to isolate tests. A Registry is safe for concurrent use. Each lookup and
+// change is atomic, but conversions combine several lookups and may observe a
+// change made concurrently in between.
+type Registry struct {
+	// mu guards the registry maps, flags and caches, i.e. every field but the
+	// denomSubscribers.
+	mu sync.RWMutex
+
+	// denomUnits contains a mapping of denomination mapped to their respective unit
+	// multipliers (e.g. 1atom = 10^-6uatom).
+	denomUnits map[string]types.Dec
+
+	// baseDenom is the denom of smallest unit registered
+	baseDenom map[string]string
+
+	// denomAliases contains a mapping of denomination mapped to their optional
+	// human-friendly display alias (e.g. atom -> ATOM).
+	denomAliases map[string]string
+
+	// aliasDenoms is the reverse mapping of denomAliases.
+	aliasDenoms map[string]string
+
+	// deprecatedDenoms contains the denominations flagged as deprecated. They stay
+	// convertible so legacy balances can still be handled.
+	deprecatedDenoms map[string]bool
+
+	// dustThresholds contains the minimum amount a conversion into a denomination
+	// must yield, see ConvertCoinDustAware.
+	dustThresholds map[string]types.Int
+
+	// crossRates contains the registered conversion rates between base denoms, see
+	// RegisterCrossRate. Both directions of a pair are stored.
+	crossRates map[denomPair]types.Dec
+
+	// registryLocked rejects further registrations once set, see
+	// LockDenomRegistry.
+	registryLocked bool
+
+	// caseInsensitiveLookup makes GetDenomUnit and GetBaseDenom fall back to the
+	// lower case denom, see SetCaseInsensitiveDenomLookup.
+	caseInsensitiveLookup bool
+
+	// nativeDenom is the native staking denom of the chain, see SetNativeDenom.
+	nativeDenom string
+
+	// baseDenomCache memoizes the base denom resolution of NormalizeCoin and
+	// NormalizeDecCoin. It is populated lazily and reset on registry changes.
+	baseDenomCache map[string]string
+
+	// exponentDeltaCache memoizes the exponentDelta of converted denom pairs. It is
+	// populated lazily and reset on registry changes.
+	exponentDeltaCache map[denomPair]exponentDelta
+
+	// denomSubscribers contains the channels of the SubscribeDenomChanges
+	// subscribers. It has its own mutex so that consumers can unsubscribe from
+	// their own goroutines while the registry is being changed.
+	denomSubscribersMu sync.Mutex
+	denomSubscribers   map[chan DenomChangeEvent]struct{}
+}
+
+// NewRegistry returns an empty, unlocked registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		denomUnits:         map[string]types.Dec{},
+		baseDenom:          map[string]string{},
+		denomAliases:       map[string]string{},
+		aliasDenoms:        map[string]string{},
+		deprecatedDenoms:   map[string]bool{},
+		dustThresholds:     map[string]types.Int{},
+		crossRates:         map[denomPair]types.Dec{},
+		baseDenomCache:     map[string]string{},
+		exponentDeltaCache: map[denomPair]exponentDelta{},
+		denomSubscribers:   map[chan DenomChangeEvent]struct{}{},
+	}
+}
+
+// defaultRegistry is the registry the package level functions operate on.
+var defaultRegistry = NewRegistry()
+
+// RegisterDenom registers a denomination with a corresponding unit. If the
+// registry is locked, if the denomination is already registered, if the base
+// unit is larger than the unit, if a denomination registered as its own base
+// has a unit other than 1 or if the base is already registered with another
+// unit, an error will be returned.
+func (r *Registry) RegisterDenom(denom string, unit types.Dec, bDenom string, bUnit types.Dec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.registerDenom(denom, unit, bDenom, bUnit)
+}
+
+// registerDenom implements RegisterDenom, r.mu must be held.
+func (r *Registry) registerDenom(denom string, unit types.Dec, bDenom string, bUnit types.Dec) error {
+	if r.registryLocked {
+		return fmt.Errorf("denom registry is locked, cannot register %s", denom)
+	}
+
+	if err := types.ValidateDenom(denom); err != nil {
+		return err
+	}
+
+	if _, ok := r.denomUnits[denom]; ok {
+		return fmt.Errorf("denom %s already registered", denom)
+	}
+
+	// the base denom is the smallest unit, swapped units would invert conversions
+	if bUnit.GT(unit) {
+		return fmt.Errorf("base denom %s unit %s is larger than denom %s unit %s", bDenom, bUnit, denom, unit)
+	}
+
+	// a denom registered as its own base is the smallest whole unit
+	if denom == bDenom && (!unit.Equal(types.OneDec()) || !bUnit.Equal(types.OneDec())) {
+		return fmt.Errorf("denom %s registered as its own base must have unit 1, got %s", denom, unit)
+	}
+
+	// an already registered base keeps its unit, rescaling it would silently
+	// change every conversion of the denoms already based on it
+	if registered, ok := r.denomUnits[bDenom]; ok && !registered.Equal(bUnit) {
+		return fmt.Errorf("base denom %s is registered with unit %s, got %s", bDenom, registered, bUnit)
+	}
+
+	r.denomUnits[denom] = unit
+	r.denomUnits[bDenom] = bUnit
+	r.baseDenom[denom] = bDenom
+	r.baseDenom[bDenom] = bDenom
+	r.invalidateDenomCaches()
+	r.publishDenomChange(DenomRegistered, denom)
+	return nil
+}
+
+// UpdateDenomUnit replaces the unit of a registered denomination in place,
+// e.g. to correct a wrong unit during an upgrade, keeping its base, alias and
+// flags. The unit must be positive and keep the base the smallest unit: a
+// denomination can't get a unit below its base's, nor a base one above any of
+// its denominations'. A denomination that is its own base and the base of no
+// other keeps unit 1, as enforced by RegisterDenom. If the registry is locked
+// or the denomination isn't registered, an error is returned.
+func (r *Registry) UpdateDenomUnit(denom string, newUnit types.Dec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.registryLocked {
+		return fmt.Errorf("denom registry is locked, cannot update %s", denom)
+	}
+
+	base, ok := r.baseDenom[denom]
+	if !ok {
+		return fmt.Errorf("denom not registered: %s", denom)
+	}
+	if newUnit.IsNil() || !newUnit.IsPositive() {
+		return fmt.Errorf("unit of denom %s must be positive: %s", denom, newUnit)
+	}
+
+	standalone := base == denom
+	for other, otherBase := range r.baseDenom {
+		switch {
+		case other == denom:
+		case base == denom && otherBase == denom && newUnit.GT(r.denomUnits[other]):
+			return fmt.Errorf("base denom %s unit %s is larger than denom %s unit %s", denom, newUnit, other, r.denomUnits[other])
+		case other == base && newUnit.LT(r.denomUnits[base]):
+			return fmt.Errorf("base denom %s unit %s is larger than denom %s unit %s", base, r.denomUnits[base], denom, newUnit)
+		}
+		if other != denom && otherBase == denom {
+			standalone = false
+		}
+	}
+
+	if standalone && !newUnit.Equal(types.OneDec()) {
+		return fmt.Errorf("denom %s registered as its own base must have unit 1, got %s", denom, newUnit)
+	}
+
+	r.denomUnits[denom] = newUnit
+	r.invalidateDenomCaches()
+	r.publishDenomChange(DenomUpdated, denom)
+	return nil
+}
+
+// DeregisterDenom removes a denomination from the registry, along with its
+// alias, deprecation flag, dust threshold and cross rates. A base denomination
+// can only be removed once no other denomination normalizes to it. If the
+// registry is locked or the denomination isn't registered, an error is
+// returned.
+func (r *Registry) DeregisterDenom(denom string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.registryLocked {
+		return fmt.Errorf("denom registry is locked, cannot deregister %s", denom)
+	}
+
+	base, ok := r.baseDenom[denom]
+	if !ok {
+		return fmt.Errorf("denom not registered: %s", denom)
+	}
+
+	if base == denom {
+		for other, otherBase := range r.baseDenom {
+			if other != denom && otherBase == denom {
+				return fmt.Errorf("base denom %s is still the base of %s", denom, other)
+			}
+		}
+		for pair := range r.crossRates {
+			if pair.src == denom || pair.dst == denom {
+				delete(r.crossRates, pair)
+			}
+		}
+	}
+
+	if alias, ok := r.denomAliases[denom]; ok {
+		delete(r.aliasDenoms, alias)
+		delete(r.denomAliases, denom)
+	}
+	if r.nativeDenom == denom {
+		r.nativeDenom = ""
+	}
+	delete(r.denomUnits, denom)
+	delete(r.baseDenom, denom)
+	delete(r.deprecatedDenoms, denom)
+	delete(r.dustThresholds, denom)
+	r.invalidateDenomCaches()
+	r.publishDenomChange(DenomDeregistered, denom)
+	return nil
+}
+
+// SubscribeDenomChanges returns a channel receiving every later registry
+// change, and a function unsubscribing and closing it. The channel buffers
+// denomChangeBuffer events; registration never blocks on a slow consumer,
+// events that don't fit in a full buffer are dropped for that subscriber.
+func (r *Registry) SubscribeDenomChanges() (<-chan DenomChangeEvent, func()) {
+	ch := make(chan DenomChangeEvent, denomChangeBuffer)
+
+	r.denomSubscribersMu.Lock()
+	r.denomSubscribers[ch] = struct{}{}
+	r.denomSubscribersMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			r.denomSubscribersMu.Lock()
+			delete(r.denomSubscribers, ch)
+			r.denomSubscribersMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// publishDenomChange sends an event to every subscriber with room left in its
+// buffer.
+func (r *Registry) publishDenomChange(kind DenomChangeKind, denom string) {
+	r.denomSubscribersMu.Lock()
+	defer r.denomSubscribersMu.Unlock()
+
+	event := DenomChangeEvent{Kind: kind, Denom: denom}
+	for ch := range r.denomSubscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// RegisterDenomsFromString registers the denominations of a comma separated
+// list of display:base:exponent entries, e.g. "atom:uatom:6,btc:satoshi:8".
+// A display denom is 10^exponent base units. Entries of a base that isn't
+// registered yet get unit 1 and their base unit 10^-exponent, later entries of
+// the same base are scaled from the base unit already registered, e.g.
+// "atom:uatom:6,matom:uatom:3" gives matom unit 10^-3. The whole spec is
+// validated before anything is registered, so a failing entry registers
+// nothing.
+func (r *Registry) RegisterDenomsFromString(spec string) error {
+	type entry struct {
+		display, base string
+		exponent      int
+	}
+
+	var entries []entry
+	for _, raw := range strings.Split(spec, ",") {
+		raw = strings.TrimSpace(raw)
+		parts := strings.Split(raw, ":")
+		if len(parts) != 3 {
+			return fmt.Errorf("invalid denom entry %q: expected display:base:exponent", raw)
+		}
+
+		exponent, err := strconv.Atoi(parts[2])
+		if err != nil || exponent < 0 || exponent > types.Precision {
+			return fmt.Errorf("invalid denom entry %q: exponent must be an integer in [0, %d]", raw, types.Precision)
+		}
+		for _, denom := range parts[:2] {
+			if err := types.ValidateDenom(denom); err != nil {
+				return fmt.Errorf("invalid denom entry %q: %w", raw, err)
+			}
+		}
+
+		entries = append(entries, entry{display: parts[0], base: parts[1], exponent: exponent})
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// dry run on a copy first, the real registrations then can't fail halfway
+	dryRun := r.unitsSnapshot()
+	for _, e := range entries {
+		if err := dryRun.registerDenomEntry(e.display, e.base, e.exponent); err != nil {
+			return fmt.Errorf("denom entry %s:%s:%d: %w", e.display, e.base, e.exponent, err)
+		}
+	}
+	for _, e := range entries {
+		if err := r.registerDenomEntry(e.display, e.base, e.exponent); err != nil {
+			return fmt.Errorf("denom entry %s:%s:%d: %w", e.display, e.base, e.exponent, err)
+		}
+	}
+	return nil
+}
+
+// registerDenomEntry registers a display denom worth 10^exponent units of its
+// base, see RegisterDenomsFromString. r.mu must be held.
+func (r *Registry) registerDenomEntry(display, base string, exponent int) error {
+	bUnit, ok := r.denomUnits[base]
+	if !ok {
+		bUnit = powerOfTen(-exponent)
+	}
+	return r.registerDenom(display, bUnit.Mul(powerOfTen(exponent)), base, bUnit)
+}
+
+// unitsSnapshot returns a registry holding a copy of the units, bases and lock
+// state of r, without subscribers, to dry run registrations on. r.mu must be
+// held.
+func (r *Registry) unitsSnapshot() *Registry {
+	snapshot := NewRegistry()
+	for denom, unit := range r.denomUnits {
+		snapshot.denomUnits[denom] = unit
+	}
+	for denom, base := range r.baseDenom {
+		snapshot.baseDenom[denom] = base
+	}
+	snapshot.registryLocked = r.registryLocked
+	return snapshot
+}
+
+// LockDenomRegistry locks the registry once the init time registrations are
+// done: any later RegisterDenom call returns an error. The lock can't be
+// lifted.
+func (r *Registry) LockDenomRegistry() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.registryLocked = true
+}
+
+// IsDenomRegistryLocked returns if the registry was locked by
+// LockDenomRegistry.
+func (r *Registry) IsDenomRegistryLocked() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.registryLocked
+}
+
+// RegisterDenomWithAlias registers a denomination like RegisterDenom along
+// with a human-friendly display alias. Aliases must be valid denoms, unique
+// across the registry and not registered as denoms themselves, which would
+// make them ambiguous.
+func (r *Registry) RegisterDenomWithAlias(denom, alias string, unit types.Dec, bDenom string, bUnit types.Dec) error {
+	if alias == "" {
+		return fmt.Errorf("alias of denom %s cannot be empty", denom)
+	}
+	if err := types.ValidateDenom(alias); err != nil {
+		return fmt.Errorf("invalid alias of denom %s: %w", denom, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if other, ok := r.aliasDenoms[alias]; ok {
+		return fmt.Errorf("alias %s already registered for denom %s", alias, other)
+	}
+	if _, ok := r.denomUnits[alias]; ok || alias == denom || alias == bDenom {
+		return fmt.Errorf("alias %s of denom %s is a registered denom", alias, denom)
+	}
+
+	if err := r.registerDenom(denom, unit, bDenom, bUnit); err != nil {
+		return err
+	}
+
+	r.denomAliases[denom] = alias
+	r.aliasDenoms[alias] = denom
+	return nil
+}
+
+// GetDenomAlias returns the display alias of a denomination. A boolean is
+// returned if the denomination has an alias registered.
+func (r *Registry) GetDenomAlias(denom string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alias, ok := r.denomAliases[denom]
+	return alias, ok
+}
+
+// registeredSpelling returns the denomination as registered, which differs
+// from the given one only under case-insensitive lookup.
+func (r *Registry) registeredSpelling(denom string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if lookup := r.lookupDenom(denom); lookup != denom {
+		if _, ok := r.denomUnits[lookup]; ok {
+			return lookup
+		}
+	}
+	return denom
+}
+
+// resolveDenomAlias returns the canonical denomination of an alias, or the
+// given denomination if it is not an alias. An alias that is also registered
+// as a different denomination is ambiguous and returns an error.
+func (r *Registry) resolveDenomAlias(denom string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	canonical, ok := r.aliasDenoms[denom]
+	if !ok || canonical == denom {
+		return denom, nil
+	}
+
+	if _, registered := r.denomUnits[denom]; registered {
+		return "", fmt.Errorf("ambiguous denom %s: registered denom and alias of %s", denom, canonical)
+	}
+
+	return canonical, nil
+}
+
+// DeprecateDenom flags a registered denomination as deprecated. Deprecated
+// denominations remain registered and convertible, the flag is informational
+// only, e.g. for UIs to discourage their use.
+func (r *Registry) DeprecateDenom(denom string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.denomUnits[denom]; !ok {
+		return fmt.Errorf("denom not registered: %s", denom)
+	}
+
+	r.deprecatedDenoms[denom] = true
+	r.publishDenomChange(DenomDeprecated, denom)
+	return nil
+}
+
+// IsDenomDeprecated returns if a denomination is flagged as deprecated.
+func (r *Registry) IsDenomDeprecated(denom string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.deprecatedDenoms[denom]
+}
+
+// SetDenomDustThreshold sets the minimum amount, expressed in the denomination
+// itself, that ConvertCoinDustAware may convert into a registered denomination.
+// A zero threshold removes it.
+func (r *Registry) SetDenomDustThreshold(denom string, threshold types.Int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.denomUnits[denom]; !ok {
+		return fmt.Errorf("denom not registered: %s", denom)
+	}
+	if threshold.IsNegative() {
+		return fmt.Errorf("dust threshold of %s is negative: %s", denom, threshold)
+	}
+
+	if threshold.IsZero() {
+		delete(r.dustThresholds, denom)
+		return nil
+	}
+	r.dustThresholds[denom] = threshold
+	return nil
+}
+
+// SetNativeDenom sets the native staking denom of the chain. The denom must be
+// registered.
+func (r *Registry) SetNativeDenom(denom string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.denomUnits[denom]; !ok {
+		return fmt.Errorf("denom not registered: %s", denom)
+	}
+
+	r.nativeDenom = denom
+	return nil
+}
+
+// GetNativeDenom returns the native staking denom of the chain, erroring if
+// none was set.
+func (r *Registry) GetNativeDenom() (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.nativeDenom == "" {
+		return "", fmt.Errorf("no native denom is set")
+	}
+	return r.nativeDenom, nil
+}
+
+// SetCaseInsensitiveDenomLookup enables or disables case-insensitive lookups in
+// GetDenomUnit and GetBaseDenom. When enabled, a denomination that isn't
+// registered as is gets looked up in lower case, e.g. ATOM resolves to atom.
+// Lookups are case-sensitive by default.
+func (r *Registry) SetCaseInsensitiveDenomLookup(enabled bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.caseInsensitiveLookup = enabled
+	r.invalidateDenomCaches()
+}
+
+// lookupDenom returns the registered spelling of a denomination, honoring
+// SetCaseInsensitiveDenomLookup. r.mu must be held.
+func (r *Registry) lookupDenom(denom string) string {
+	if _, ok := r.denomUnits[denom]; ok || !r.caseInsensitiveLookup {
+		return denom
+	}
+	return strings.ToLower(denom)
+}
+
+// GetDenomUnit returns a unit for a given denomination if it exists. A boolean
+// is returned if the denomination is registered.
+func (r *Registry) GetDenomUnit(denom string) (types.Dec, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.getDenomUnit(denom)
+}
+
+// getDenomUnit implements GetDenomUnit, r.mu must be held.
+func (r *Registry) getDenomUnit(denom string) (types.Dec, bool) {
+	if err := types.ValidateDenom(denom); err != nil {
+		return types.ZeroDec(), false
+	}
+
+	unit, ok := r.denomUnits[r.lookupDenom(denom)]
+	if !ok {
+		return types.ZeroDec(), false
+	}
+
+	return unit, true
+}
+
+// GetBaseDenom returns the denom of smallest unit registered
+func (r *Registry) GetBaseDenom(denom string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.getBaseDenom(denom)
+}
+
+// getBaseDenom implements GetBaseDenom, r.mu must be held.
+func (r *Registry) getBaseDenom(denom string) (string, error) {
+	denom = r.lookupDenom(denom)
+	if r.baseDenom[denom] == "" {
+		return "", fmt.Errorf("no denom is registered")
+	}
+	return r.baseDenom[denom], nil
+}
+
+// cachedBaseDenom returns the base denom of a denomination through
+// baseDenomCache, resolving and caching it on a miss. Hits only take the read
+// lock.
+func (r *Registry) cachedBaseDenom(denom string) (string, error) {
+	r.mu.RLock()
+	base, ok := r.baseDenomCache[denom]
+	r.mu.RUnlock()
+	if ok {
+		return base, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// another caller may have filled the entry in between
+	if base, ok := r.baseDenomCache[denom]; ok {
+		return base, nil
+	}
+
+	base, err := r.getBaseDenom(denom)
+	if err != nil {
+		return "", err
+	}
+
+	r.baseDenomCache[denom] = base
+	return base, nil
+}
+
+// cachedExponentDelta returns the exponentDelta of a conversion between two
+// denoms through exponentDeltaCache, computing and caching it on a miss. Hits
+// only take the read lock.
+func (r *Registry) cachedExponentDelta(src, dst string, srcUnit, dstUnit types.Dec) exponentDelta {
+	pair := denomPair{src, dst}
+
+	r.mu.RLock()
+	delta, ok := r.exponentDeltaCache[pair]
+	r.mu.RUnlock()
+	if ok {
+		return delta
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// another caller may have filled the entry in between
+	if delta, ok := r.exponentDeltaCache[pair]; ok {
+		return delta
+	}
+
+	delta = exponentDelta{}
+	ratio := srcUnit.Quo(dstUnit)
+	for exp := -types.Precision; exp <= types.Precision; exp++ {
+		// the quotient is rounded, check exactly by scaling the smaller unit up
+		if ratio.Equal(powerOfTen(exp)) && scalesExactly(srcUnit, dstUnit, exp) {
+			abs := exp
+			if abs < 0 {
+				abs = -abs
+			}
+			delta = exponentDelta{delta: exp, scale: types.NewIntWithDecimal(1, abs), clean: true}
+			break
+		}
+	}
+
+	r.exponentDeltaCache[pair] = delta
+	return delta
+}
+
+// scalesExactly returns if srcUnit is exactly 10^exp times dstUnit. Only the
+// smaller unit is multiplied, by a positive power of ten, which is exact.
+func scalesExactly(srcUnit, dstUnit types.Dec, exp int) bool {
+	if exp >= 0 {
+		return dstUnit.Mul(powerOfTen(exp)).Equal(srcUnit)
+	}
+	return srcUnit.Mul(powerOfTen(-exp)).Equal(dstUnit)
+}
+
+// powerOfTen returns 10^exp for exp in [-Precision, Precision].
+func powerOfTen(exp int) types.Dec {
+	if exp < 0 {
+		return types.NewDecWithPrec(1, int64(-exp))
+	}
+	return types.NewDecFromInt(types.NewIntWithDecimal(1, exp))
+}
+
+// invalidateDenomCaches drops every memoized base denom and exponent delta.
+// r.mu must be held.
+func (r *Registry) invalidateDenomCaches() {
+	r.baseDenomCache = map[string]string{}
+	r.exponentDeltaCache = map[denomPair]exponentDelta{}
+}
+
+// DenomInfo gathers the registry data of a single denom.
+type DenomInfo struct {
+	Denom     string
+	BaseDenom string
+	Unit      types.Dec
+	BaseUnit  types.Dec
+	// Exponent is the number of decimal places between the denom and its
+	// base, the floor of log10(Unit/BaseUnit), e.g. 6 for atom over uatom.
+	Exponent int
+}
+
+// GetDenomInfo returns the registry data of a denom, erroring if the denom or
+// its base is not registered.
+func (r *Registry) GetDenomInfo(denom string) (DenomInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.getDenomInfo(denom)
+}
+
+// getDenomInfo implements GetDenomInfo, r.mu must be held.
+func (r *Registry) getDenomInfo(denom string) (DenomInfo, error) {
+	unit, ok := r.getDenomUnit(denom)
+	if !ok {
+		return DenomInfo{}, fmt.Errorf("denom not registered: %s", denom)
+	}
+
+	base, err := r.getBaseDenom(denom)
+	if err != nil {
+		return DenomInfo{}, fmt.Errorf("%s: %w", denom, err)
+	}
+
+	baseUnit, ok := r.getDenomUnit(base)
+	if !ok {
+		return DenomInfo{}, fmt.Errorf("base denom %s of %s not registered", base, denom)
+	}
+
+	return DenomInfo{
+		Denom:     denom,
+		BaseDenom: base,
+		Unit:      unit,
+		BaseUnit:  baseUnit,
+		Exponent:  len(unit.Quo(baseUnit).TruncateInt().String()) - 1,
+	}, nil
+}
+
+// DenomStep returns the smallest amount representable in a denom, one unit of
+// its base denom expressed in it, e.g. 0.000001 for atom over uatom and 1 for
+// a base denom.
+func (r *Registry) DenomStep(denom string) (types.Dec, error) {
+	resolved, err := r.resolveDenomAlias(denom)
+	if err != nil {
+		return types.Dec{}, err
+	}
+
+	info, err := r.GetDenomInfo(resolved)
+	if err != nil {
+		return types.Dec{}, err
+	}
+	return info.BaseUnit.Quo(info.Unit), nil
+}
+
+// DenomUnit is a denom along with its exponent over its base denom, as used by
+// bank metadata.
+type DenomUnit struct {
+	Denom    string
+	Exponent int
+}
+
+// GetBaseUnits returns every denom normalizing to the given base denom, the
+// base itself included with exponent 0, sorted by exponent and then by denom.
+// An error is returned if bDenom isn't a registered base denom.
+func (r *Registry) GetBaseUnits(bDenom string) ([]DenomUnit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if base, ok := r.baseDenom[bDenom]; !ok || base != bDenom {
+		return nil, fmt.Errorf("base denom not registered: %s", bDenom)
+	}
+
+	units := []DenomUnit{}
+	for denom, base := range r.baseDenom {
+		if base != bDenom {
+			continue
+		}
+		info, err := r.getDenomInfo(denom)
+		if err != nil {
+			return nil, err
+		}
+		units = append(units, DenomUnit{Denom: denom, Exponent: info.Exponent})
+	}
+
+	sort.Slice(units, func(i, j int) bool {
+		if units[i].Exponent != units[j].Exponent {
+			return units[i].Exponent < units[j].Exponent
+		}
+		return units[i].Denom < units[j].Denom
+	})
+	return units, nil
+}
+
+// DisplayDenomsByMagnitude returns the denoms registered over a base denom,
+// the base included, from the largest unit to the smallest, e.g. atom, matom,
+// uatom. Denoms with equal units are sorted by name. It errors if bDenom isn't
+// a registered base denom.
+func (r *Registry) DisplayDenomsByMagnitude(bDenom string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if base, ok := r.baseDenom[bDenom]; !ok || base != bDenom {
+		return nil, fmt.Errorf("base denom not registered: %s", bDenom)
+	}
+
+	denoms := []string{}
+	for denom, base := range r.baseDenom {
+		if base == bDenom {
+			denoms = append(denoms, denom)
+		}
+	}
+
+	sort.Slice(denoms, func(i, j int) bool {
+		ui, uj := r.denomUnits[denoms[i]], r.denomUnits[denoms[j]]
+		if !ui.Equal(uj) {
+			return ui.GT(uj)
+		}
+		return denoms[i] < denoms[j]
+	})
+	return denoms, nil
+}
+
+// DenomEdge is a directed edge of the denom graph, from a display denom to its
+// base denom, weighted by the number of base units in one From unit.
+type DenomEdge struct {
+	From   string
+	To     string
+	Weight types.Dec
+}
+
+// DenomGraph returns an edge from every registered non-base denom to its base
+// denom, sorted by base then by weight and denom, e.g. atom -> uatom with a
+// weight of 1000000.
+func (r *Registry) DenomGraph() []DenomEdge {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	edges := []DenomEdge{}
+	for denom, base := range r.baseDenom {
+		if denom == base {
+			continue
+		}
+		edges = append(edges, DenomEdge{
+			From:   denom,
+			To:     base,
+			Weight: r.denomUnits[denom].Quo(r.denomUnits[base]),
+		})
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		if !edges[i].Weight.Equal(edges[j].Weight) {
+			return edges[i].Weight.LT(edges[j].Weight)
+		}
+		return edges[i].From < edges[j].From
+	})
+	return edges
+}
+
+// CommonBaseDenom returns the base denom every coin of the set normalizes to,
+// erroring if the set is empty, a denom is unregistered or the coins don't
+// share a base.
+func (r *Registry) CommonBaseDenom(coins types.Coins) (string, error) {
+	if coins.Empty() {
+		return "", fmt.Errorf("no coins to find a common base denom of")
+	}
+
+	common := ""
+	for _, coin := range coins {
+		denom, err := r.resolveDenomAlias(coin.Denom)
+		if err != nil {
+			return "", err
+		}
+		base, err := r.GetBaseDenom(denom)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", denom, err)
+		}
+
+		if common == "" {
+			common = base
+		} else if base != common {
+			return "", fmt.Errorf("coins don't share a base denom: %s and %s", common, base)
+		}
+	}
+
+	return common, nil
+}
+
+// GetEquivalentDenoms returns the other registered denoms sharing the base and
+// the unit of the given denom, sorted. Conversions between equivalent denoms
+// leave the amount unchanged.
+func (r *Registry) GetEquivalentDenoms(denom string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unit, ok := r.denomUnits[denom]
+	if !ok {
+		return []string{}
+	}
+	base := r.baseDenom[denom]
+
+	denoms := []string{}
+	for other, otherUnit := range r.denomUnits {
+		if other != denom && r.baseDenom[other] == base && otherUnit.Equal(unit) {
+			denoms = append(denoms, other)
+		}
+	}
+
+	sort.Strings(denoms)
+	return denoms
+}
+
+// ListBaseDenoms returns the distinct base denoms of the registry, sorted.
+func (r *Registry) ListBaseDenoms() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	bases := make([]string, 0, len(r.baseDenom))
+	for denom, base := range r.baseDenom {
+		// every base maps onto itself, display denoms map onto their base
+		if denom == base {
+			bases = append(bases, base)
+		}
+	}
+
+	sort.Strings(bases)
+	return bases
+}
+
+// RegisteredDenomCount returns the number of display denoms in the registry,
+// i.e. denoms whose base is another denom. Base denoms, whether registered
+// only as the bDenom of RegisterDenom or as a denom of their own, are not
+// counted, see ListBaseDenoms for those.
+func (r *Registry) RegisteredDenomCount() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for denom, base := range r.baseDenom {
+		if denom != base {
+			count++
+		}
+	}
+	return count
+}
+
+// ValidateDenomRegistry checks the consistency of the registry: every denom
+// has a positive unit and a registered base, and every base maps onto itself.
+// It is meant to be run once all init() registrations are done to fail fast on
+// misconfigured registrations.
+func (r *Registry) ValidateDenomRegistry() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	denoms := make([]string, 0, len(r.denomUnits))
+	for denom := range r.denomUnits {
+		denoms = append(denoms, denom)
+	}
+	sort.Strings(denoms)
+
+	for _, denom := range denoms {
+		if !r.denomUnits[denom].IsPositive() {
+			return fmt.Errorf("denom %s has non-positive unit %s", denom, r.denomUnits[denom])
+		}
+
+		base, ok := r.baseDenom[denom]
+		if !ok {
+			return fmt.Errorf("denom %s has no base denom registered", denom)
+		}
+		if _, ok := r.denomUnits[base]; !ok {
+			return fmt.Errorf("base denom %s of %s is not registered", base, denom)
+		}
+		if r.baseDenom[base] != base {
+			return fmt.Errorf("base denom %s of %s does not map onto itself", base, denom)
+		}
+	}
+
+	return nil
+}
+
+// DenomRegistryEntry is the wire format of a single registered denom.
+type DenomRegistryEntry struct {
+	Denom      string `json:"denom"`
+	Base       string `json:"base"`
+	Unit       string `json:"unit"`
+	Deprecated bool   `json:"deprecated,omitempty"`
+}
+
+// ListRegisteredDenoms returns an entry for every registered denom, sorted by
+// denom, flagging the deprecated ones.
+func (r *Registry) ListRegisteredDenoms() []DenomRegistryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]DenomRegistryEntry, 0, len(r.denomUnits))
+	for denom, unit := range r.denomUnits {
+		entries = append(entries, DenomRegistryEntry{
+			Denom:      denom,
+			Base:       r.baseDenom[denom],
+			Unit:       unit.String(),
+			Deprecated: r.deprecatedDenoms[denom],
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Denom < entries[j].Denom })
+	return entries
+}
+
+// MarshalDenomRegistryJSON returns the registry as a JSON array of entries
+// sorted by denom. The output is deterministic so that dumps taken on
+// different nodes can be diffed.
+func (r *Registry) MarshalDenomRegistryJSON() ([]byte, error) {
+	return json.Marshal(r.ListRegisteredDenoms())
+}
+
+// AreConvertible returns if coins of fromDenom can be converted to toDenom:
+// both must be registered, directly or by alias, and either share a base denom
+// or have a cross rate registered between their bases (see
+// ConvertCoinCrossBase).
+func (r *Registry) AreConvertible(fromDenom, toDenom string) bool {
+	bases := make([]string, 2)
+	for i, denom := range []string{fromDenom, toDenom} {
+		canonical, err := r.resolveDenomAlias(denom)
+		if err != nil {
+			return false
+		}
+		if _, ok := r.GetDenomUnit(canonical); !ok {
+			return false
+		}
+		if bases[i], err = r.GetBaseDenom(canonical); err != nil {
+			return false
+		}
+	}
+
+	if bases[0] == bases[1] {
+		return true
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.crossRates[denomPair{bases[0], bases[1]}]
+	return ok
+}
+
+// ConvertCoin attempts to convert a coin to a given denomination. Either
+// denomination may be given by its display alias, the result is always in the
+// canonical denomination as registered, regardless of case-insensitive lookup.
+// If the given denomination is invalid, an alias is ambiguous or if neither
+// denomination is registered, an error is returned.
+func (r *Registry) ConvertCoin(coin types.Coin, denom string) (types.Coin, error) {
+	if err := types.ValidateDenom(denom); err != nil {
+		return types.Coin{}, err
+	}
+
+	denom, err := r.resolveDenomAlias(denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+	denom = r.registeredSpelling(denom)
+
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	srcUnit, ok := r.GetDenomUnit(srcDenom)
+	if !ok {
+		return types.Coin{}, fmt.Errorf("source denom not registered: %s", coin.Denom)
+	}
+
+	dstUnit, ok := r.GetDenomUnit(denom)
+	if !ok {
+		return types.Coin{}, fmt.Errorf("destination denom not registered: %s", denom)
+	}
+
+	if srcUnit.Equal(dstUnit) {
+		return types.NewCoin(denom, coin.Amount), nil
+	}
+
+	// units a power of ten apart are scaled with integer arithmetic only
+	if delta := r.cachedExponentDelta(srcDenom, denom, srcUnit, dstUnit); delta.clean {
+		if delta.delta >= 0 {
+			return types.NewCoin(denom, coin.Amount.Mul(delta.scale)), nil
+		}
+		return types.NewCoin(denom, coin.Amount.Quo(delta.scale)), nil
+	}
 
-// denomUnits contains a mapping of denomination mapped to their respective unit
-// multipliers (e.g. 1atom = 10^-6uatom).
-var denomUnits = map[string]types.Dec{}
+	return types.NewCoin(denom, convertAmountDec(coin.Amount, srcUnit, dstUnit)), nil
+}
 
-// baseDenom is the denom of smallest unit registered
-var baseDenom = map[string]string{}
+// ExponentDelta returns the base-10 exponent of the unit of toDenom over the
+// unit of fromDenom, e.g. 6 from uatom to atom and -6 from atom to uatom, so
+// that amounts convert from fromDenom to toDenom by dividing by 10^delta. It
+// errors if a denom isn't registered or the units aren't a power of ten apart.
+func (r *Registry) ExponentDelta(fromDenom, toDenom string) (int, error) {
+	from, err := r.resolveDenomAlias(fromDenom)
+	if err != nil {
+		return 0, err
+	}
+	to, err := r.resolveDenomAlias(toDenom)
+	if err != nil {
+		return 0, err
+	}
 
-// RegisterDenom registers a denomination with a corresponding unit. If the
-// denomination is already registered, an error will be returned.
-func RegisterDenom(denom string, unit types.Dec, bDenom string, bUnit types.Dec) error {
-	if err := types.ValidateDenom(denom); err != nil {
-		return err
+	fromUnit, ok := r.GetDenomUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("source denom not registered: %s", fromDenom)
+	}
+	toUnit, ok := r.GetDenomUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("destination denom not registered: %s", toDenom)
 	}
 
-	if _, ok := denomUnits[denom]; ok {
-		return fmt.Errorf("denom %s already registered", denom)
+	delta := r.cachedExponentDelta(from, to, fromUnit, toUnit)
+	if !delta.clean {
+		return 0, fmt.Errorf("units of %s and %s are not a power of ten apart", fromDenom, toDenom)
 	}
+	return -delta.delta, nil
+}
 
-	denomUnits[denom] = unit
-	denomUnits[bDenom] = bUnit
-	baseDenom[denom] = bDenom
-	baseDenom[bDenom] = bDenom
-	return nil
+// ConvertCoinWithRatio converts a coin to a given denomination by multiplying
+// its amount by ratio, truncating the result, for what-if analysis in
+// simulations. It bypasses the registry entirely: neither denomination needs
+// to be registered and registered units are ignored. Like types.NewCoin, it
+// panics on an invalid denomination or a negative result.
+func ConvertCoinWithRatio(coin types.Coin, denom string, ratio types.Dec) types.Coin {
+	return types.NewCoin(denom, ratio.MulInt(coin.Amount).TruncateInt())
 }
 
-// GetDenomUnit returns a unit for a given denomination if it exists. A boolean
-// is returned if the denomination is registered.
-func GetDenomUnit(denom string) (types.Dec, bool) {
+// convertAmountDec converts an amount between units with decimal arithmetic,
+// truncating the result.
+func convertAmountDec(amount types.Int, srcUnit, dstUnit types.Dec) types.Int {
+	return types.NewDecFromInt(amount).Mul(srcUnit).Quo(dstUnit).TruncateInt()
+}
+
+// ConvertCoinSafe behaves like ConvertCoin but returns an error when the
+// conversion truncates a non-zero amount to zero (e.g. 5uatom to atom), so
+// callers can warn instead of silently dropping the balance.
+func (r *Registry) ConvertCoinSafe(coin types.Coin, denom string) (types.Coin, error) {
+	newCoin, err := r.ConvertCoin(coin, denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	if !coin.Amount.IsZero() && newCoin.Amount.IsZero() {
+		return types.Coin{}, fmt.Errorf("converting %s to %s truncates to zero", coin, denom)
+	}
+
+	return newCoin, nil
+}
+
+// ConvertCoinDustAware converts a coin like ConvertCoin but returns an error
+// when a non-zero result falls below the dust threshold of the destination
+// denomination, see SetDenomDustThreshold.
+func (r *Registry) ConvertCoinDustAware(coin types.Coin, denom string) (types.Coin, error) {
+	newCoin, err := r.ConvertCoin(coin, denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	r.mu.RLock()
+	threshold, ok := r.dustThresholds[newCoin.Denom]
+	r.mu.RUnlock()
+	if ok && !coin.Amount.IsZero() && newCoin.Amount.LT(threshold) {
+		return types.Coin{}, fmt.Errorf("converting %s to %s yields %s, below the dust threshold of %s", coin, denom, newCoin, threshold)
+	}
+
+	return newCoin, nil
+}
+
+// ConvertCoinMaxLoss converts a coin like ConvertCoin but returns an error when
+// the amount truncated away by the conversion exceeds maxLoss, expressed in
+// units of the coin's base denom.
+func (r *Registry) ConvertCoinMaxLoss(coin types.Coin, denom string, maxLoss types.Int) (types.Coin, error) {
+	newCoin, err := r.ConvertCoin(coin, denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+	base, err := r.GetBaseDenom(srcDenom)
+	if err != nil {
+		return types.Coin{}, fmt.Errorf("%s: %w", srcDenom, err)
+	}
+
+	original, err := r.ConvertDecCoin(types.NewDecCoin(srcDenom, coin.Amount), base)
+	if err != nil {
+		return types.Coin{}, err
+	}
+	kept, err := r.ConvertDecCoin(types.NewDecCoinFromCoin(newCoin), base)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	if loss := original.Amount.Sub(kept.Amount); loss.GT(types.NewDecFromInt(maxLoss)) {
+		return types.Coin{}, fmt.Errorf("converting %s to %s loses %s%s, more than %s", coin, denom, loss, base, maxLoss)
+	}
+
+	return newCoin, nil
+}
+
+// ConvertCoinBounds converts a coin to a given denomination like ConvertCoin,
+// returning both the truncated and the ceiling result so callers can bound the
+// rounding error. When the conversion is exact, floor equals ceil. Either
+// denomination may be given by its display alias, the results are always in
+// the canonical denomination.
+func (r *Registry) ConvertCoinBounds(coin types.Coin, denom string) (floor, ceil types.Coin, err error) {
 	if err := types.ValidateDenom(denom); err != nil {
-		return types.ZeroDec(), false
+		return types.Coin{}, types.Coin{}, err
+	}
+
+	denom, err = r.resolveDenomAlias(denom)
+	if err != nil {
+		return types.Coin{}, types.Coin{}, err
+	}
+	denom = r.registeredSpelling(denom)
+
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.Coin{}, types.Coin{}, err
 	}
 
-	unit, ok := denomUnits[denom]
+	srcUnit, ok := r.GetDenomUnit(srcDenom)
 	if !ok {
-		return types.ZeroDec(), false
+		return types.Coin{}, types.Coin{}, fmt.Errorf("source denom not registered: %s", coin.Denom)
 	}
 
-	return unit, true
+	dstUnit, ok := r.GetDenomUnit(denom)
+	if !ok {
+		return types.Coin{}, types.Coin{}, fmt.Errorf("destination denom not registered: %s", denom)
+	}
+
+	if srcUnit.Equal(dstUnit) {
+		return types.NewCoin(denom, coin.Amount), types.NewCoin(denom, coin.Amount), nil
+	}
+
+	amount := types.NewDecFromInt(coin.Amount).Mul(srcUnit).Quo(dstUnit)
+	return types.NewCoin(denom, amount.TruncateInt()), types.NewCoin(denom, amount.Ceil().TruncateInt()), nil
 }
 
-// GetBaseDenom returns the denom of smallest unit registered
-func GetBaseDenom(denom string) (string, error) {
-	if baseDenom[denom] == "" {
-		return "", fmt.Errorf("no denom is registered")
+// ConvertCoinsBestEffort converts every coin whose denom is registered to the
+// given denomination and leaves the others untouched. Coins ending up with the
+// same denom are merged, so the result is always a valid Coins.
+func (r *Registry) ConvertCoinsBestEffort(coins types.Coins, denom string) types.Coins {
+	result := types.NewCoins()
+	for _, coin := range coins {
+		newCoin, err := r.ConvertCoin(coin, denom)
+		if err != nil {
+			newCoin = coin
+		}
+		result = result.Add(newCoin)
 	}
-	return baseDenom[denom], nil
+
+	return result
 }
 
-// ConvertCoin attempts to convert a coin to a given denomination. If the given
-// denomination is invalid or if neither denomination is registered, an error
-// is returned.
-func ConvertCoin(coin types.Coin, denom string) (types.Coin, error) {
+// TotalInNative converts every coin to the native denom (see SetNativeDenom)
+// and returns their sum. The sum is truncated once, after adding up the exact
+// converted amounts. An error is returned if no native denom is set or if any
+// coin can't be converted to it.
+func (r *Registry) TotalInNative(coins types.Coins) (types.Coin, error) {
+	native, err := r.GetNativeDenom()
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	total := types.ZeroDec()
+	for _, coin := range coins {
+		converted, err := r.ConvertDecCoin(types.NewDecCoinFromCoin(coin), native)
+		if err != nil {
+			return types.Coin{}, err
+		}
+		total = total.Add(converted.Amount)
+	}
+
+	return types.NewCoin(native, total.TruncateInt()), nil
+}
+
+// RegisterCrossRate registers the rate at which 1 baseA converts into baseB,
+// along with the inverse rate, for ConvertCoinCrossBase. Both denoms must be
+// distinct registered base denoms and the rate must be positive. Registering a
+// pair again replaces its rate.
+func (r *Registry) RegisterCrossRate(baseA, baseB string, rate types.Dec) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, b := range []string{baseA, baseB} {
+		if base, ok := r.baseDenom[b]; !ok || base != b {
+			return fmt.Errorf("base denom not registered: %s", b)
+		}
+	}
+	if baseA == baseB {
+		return fmt.Errorf("cannot register a cross rate of %s to itself", baseA)
+	}
+	if !rate.IsPositive() {
+		return fmt.Errorf("cross rate of %s to %s must be positive: %s", baseA, baseB, rate)
+	}
+
+	r.crossRates[denomPair{baseA, baseB}] = rate
+	r.crossRates[denomPair{baseB, baseA}] = types.OneDec().Quo(rate)
+	return nil
+}
+
+// ExplainConvertCoin converts a coin like ConvertCoin and returns the
+// arithmetic of the conversion as a human-readable string, e.g.
+// "1500000 uatom × 1e-6 ÷ 1 = 1.5 atom (truncated to 1 atom)". Lossless
+// conversions end in "(exact)" instead.
+func (r *Registry) ExplainConvertCoin(coin types.Coin, denom string) (string, error) {
+	newCoin, err := r.ConvertCoin(coin, denom)
+	if err != nil {
+		return "", err
+	}
+
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return "", err
+	}
+	srcUnit, _ := r.GetDenomUnit(srcDenom)
+	dstUnit, _ := r.GetDenomUnit(newCoin.Denom)
+
+	exact := types.NewDecFromInt(coin.Amount).Mul(srcUnit).Quo(dstUnit)
+	result := "(exact)"
+	if !exact.Equal(types.NewDecFromInt(newCoin.Amount)) {
+		result = fmt.Sprintf("(truncated to %s %s)", newCoin.Amount, newCoin.Denom)
+	}
+
+	return fmt.Sprintf(
+		"%s %s × %s ÷ %s = %s %s %s",
+		coin.Amount, srcDenom, formatUnit(srcUnit), formatUnit(dstUnit), formatDec(exact), newCoin.Denom, result,
+	), nil
+}
+
+// formatUnit formats a denom unit, writing powers of ten in exponent notation
+// (e.g. 1e-6).
+func formatUnit(unit types.Dec) string {
+	for exp := -types.Precision; exp <= types.Precision; exp++ {
+		if unit.Equal(powerOfTen(exp)) {
+			if exp == 0 {
+				return "1"
+			}
+			return fmt.Sprintf("1e%d", exp)
+		}
+	}
+	return formatDec(unit)
+}
+
+// formatDec formats a decimal without its trailing zeros.
+func formatDec(d types.Dec) string {
+	str := d.String()
+	if strings.Contains(str, ".") {
+		str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+	}
+	return str
+}
+
+// ConvertCoinCrossBase converts a coin to a given denomination like
+// ConvertCoin, going through the registered cross rate (see RegisterCrossRate)
+// when both denominations have different bases. The result is truncated once,
+// at the end. An error is returned if no cross rate is registered between the
+// two bases.
+func (r *Registry) ConvertCoinCrossBase(coin types.Coin, denom string) (types.Coin, error) {
 	if err := types.ValidateDenom(denom); err != nil {
 		return types.Coin{}, err
 	}
 
-	srcUnit, ok := GetDenomUnit(coin.Denom)
-	if !ok {
-		return types.Coin{}, fmt.Errorf("source denom not registered: %s", coin.Denom)
+	denom, err := r.resolveDenomAlias(denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+	denom = r.registeredSpelling(denom)
+
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	srcBase, err := r.GetBaseDenom(srcDenom)
+	if err != nil {
+		return types.Coin{}, fmt.Errorf("%s: %w", srcDenom, err)
+	}
+	dstBase, err := r.GetBaseDenom(denom)
+	if err != nil {
+		return types.Coin{}, fmt.Errorf("%s: %w", denom, err)
+	}
+
+	if srcBase == dstBase {
+		return r.ConvertCoin(coin, denom)
 	}
 
-	dstUnit, ok := GetDenomUnit(denom)
+	r.mu.RLock()
+	rate, ok := r.crossRates[denomPair{srcBase, dstBase}]
+	r.mu.RUnlock()
 	if !ok {
-		return types.Coin{}, fmt.Errorf("destination denom not registered: %s", denom)
+		return types.Coin{}, fmt.Errorf("no cross rate registered from %s to %s", srcBase, dstBase)
 	}
 
-	if srcUnit.Equal(dstUnit) {
-		return types.NewCoin(denom, coin.Amount), nil
+	inSrcBase, err := r.ConvertDecCoin(types.NewDecCoin(srcDenom, coin.Amount), srcBase)
+	if err != nil {
+		return types.Coin{}, err
+	}
+	converted, err := r.ConvertDecCoin(types.NewDecCoinFromDec(dstBase, inSrcBase.Amount.Mul(rate)), denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	return types.NewCoin(denom, converted.Amount.TruncateInt()), nil
+}
+
+// ConvertCoinToDec converts a coin to a given denomination like ConvertCoin,
+// but returns the exact result as a decimal coin instead of truncating it, so
+// chained conversions don't compound truncation errors.
+func (r *Registry) ConvertCoinToDec(coin types.Coin, denom string) (types.DecCoin, error) {
+	if err := types.ValidateDenom(denom); err != nil {
+		return types.DecCoin{}, err
+	}
+
+	denom, err := r.resolveDenomAlias(denom)
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+	denom = r.registeredSpelling(denom)
+
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+
+	return r.ConvertDecCoin(types.NewDecCoin(srcDenom, coin.Amount), denom)
+}
+
+// ConvertToBestDisplay converts a coin to the registered denomination of its
+// base yielding the smallest converted amount that is still at least 1, e.g.
+// atom for 2500000uatom but uatom for 5uatom. The result is truncated like
+// ConvertCoin. Amounts below 1 in every denomination stay in the base denom.
+func (r *Registry) ConvertToBestDisplay(coin types.Coin) (types.Coin, error) {
+	srcDenom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.Coin{}, err
+	}
+	base, err := r.GetBaseDenom(srcDenom)
+	if err != nil {
+		return types.Coin{}, fmt.Errorf("%s: %w", srcDenom, err)
+	}
+
+	units, err := r.GetBaseUnits(base)
+	if err != nil {
+		return types.Coin{}, err
+	}
+
+	for i := len(units) - 1; i >= 0; i-- {
+		converted, err := r.ConvertCoinToDec(coin, units[i].Denom)
+		if err != nil {
+			return types.Coin{}, err
+		}
+		if converted.Amount.GTE(types.OneDec()) {
+			return r.ConvertCoin(coin, units[i].Denom)
+		}
+	}
+
+	return r.ConvertCoin(coin, base)
+}
+
+// FormatCoins formats a coin set for display as a comma separated list of
+// amounts in their best display denomination (see ConvertToBestDisplay),
+// e.g. "1.5 atom, 2.5 btc". Amounts are exact, not truncated. Coins of
+// unregistered denoms are formatted raw. Coins are listed by display denom.
+func (r *Registry) FormatCoins(coins types.Coins) string {
+	type displayCoin struct {
+		denom string
+		str   string
+	}
+
+	displayed := make([]displayCoin, 0, len(coins))
+	for _, coin := range coins {
+		denom, str := r.formatCoin(coin)
+		displayed = append(displayed, displayCoin{denom, str})
+	}
+	sort.SliceStable(displayed, func(i, j int) bool { return displayed[i].denom < displayed[j].denom })
+
+	parts := make([]string, len(displayed))
+	for i, coin := range displayed {
+		parts[i] = coin.str
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatCoin returns the display denom of a coin and the coin formatted for
+// FormatCoins.
+func (r *Registry) formatCoin(coin types.Coin) (string, string) {
+	display, err := r.ConvertToBestDisplay(coin)
+	if err != nil {
+		return coin.Denom, coin.String()
+	}
+	converted, err := r.ConvertCoinToDec(coin, display.Denom)
+	if err != nil {
+		return coin.Denom, coin.String()
+	}
+	return converted.Denom, formatDec(converted.Amount) + " " + converted.Denom
+}
+
+// ConvertCoinSigFigs converts a coin to a given denomination like
+// ConvertCoinToDec and rounds the result, half up, to sigFigs significant
+// figures, e.g. 1.23456789atom to 1.23atom for 3 significant figures. An error
+// is returned on unregistered denominations or a non-positive sigFigs.
+func (r *Registry) ConvertCoinSigFigs(coin types.Coin, denom string, sigFigs int) (types.DecCoin, error) {
+	if sigFigs <= 0 {
+		return types.DecCoin{}, fmt.Errorf("significant figures must be positive, is %d", sigFigs)
+	}
+
+	converted, err := r.ConvertCoinToDec(coin, denom)
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+
+	// round the Dec's integer representation, 10^Precision times the amount
+	raw := types.NewIntFromBigInt(converted.Amount.BigInt())
+	drop := len(raw.String()) - sigFigs
+	if raw.IsZero() || drop <= 0 {
+		return converted, nil
 	}
 
-	return types.NewCoin(denom, types.NewDecFromInt(coin.Amount).Mul(srcUnit).Quo(dstUnit).TruncateInt()), nil
+	scale := types.NewIntWithDecimal(1, drop)
+	rounded := raw.Quo(scale)
+	if raw.Mod(scale).MulRaw(2).GTE(scale) {
+		rounded = rounded.AddRaw(1)
+	}
+
+	amount := types.NewDecFromBigIntWithPrec(rounded.Mul(scale).BigInt(), types.Precision)
+	return types.NewDecCoinFromDec(converted.Denom, amount), nil
 }
 
 // ConvertDecCoin attempts to convert a decimal coin to a given denomination. If the given
 // denomination is invalid or if neither denomination is registered, an error
-// is returned.
-func ConvertDecCoin(coin types.DecCoin, denom string) (types.DecCoin, error) {
+// is returned. Unlike coins, decimal coins may be negative (e.g. fee refunds),
+// the sign of the amount is preserved by the conversion.
+func (r *Registry) ConvertDecCoin(coin types.DecCoin, denom string) (types.DecCoin, error) {
 	if err := types.ValidateDenom(denom); err != nil {
 		return types.DecCoin{}, err
 	}
 
-	srcUnit, ok := GetDenomUnit(coin.Denom)
+	srcUnit, ok := r.GetDenomUnit(coin.Denom)
 	if !ok {
 		return types.DecCoin{}, fmt.Errorf("source denom not registered: %s", coin.Denom)
 	}
 
-	dstUnit, ok := GetDenomUnit(denom)
+	dstUnit, ok := r.GetDenomUnit(denom)
 	if !ok {
 		return types.DecCoin{}, fmt.Errorf("destination denom not registered: %s", denom)
 	}
 
 	if srcUnit.Equal(dstUnit) {
-		return types.NewDecCoinFromDec(denom, coin.Amount), nil
+		return types.DecCoin{Denom: denom, Amount: coin.Amount}, nil
+	}
+
+	// NewDecCoinFromDec rejects negative amounts, build the coin directly
+	return types.DecCoin{Denom: denom, Amount: coin.Amount.Mul(srcUnit).Quo(dstUnit)}, nil
+}
+
+// ConvertCoinToExponent converts a coin to the denom registered over its base
+// whose unit is exactly the base unit times 10^exponent, e.g. 1500000uatom at
+// exponent 6 is 1.5atom. Denoms with equal units are tried by name. An error
+// is returned if the coin's denom has no registered base, no denom is
+// registered at that exponent or the exponent is outside [0, Precision].
+func (r *Registry) ConvertCoinToExponent(coin types.Coin, exponent int) (types.DecCoin, error) {
+	if exponent < 0 || exponent > types.Precision {
+		return types.DecCoin{}, fmt.Errorf("exponent %d out of range [0, %d]", exponent, types.Precision)
+	}
+
+	base, err := r.GetBaseDenom(coin.Denom)
+	if err != nil {
+		return types.DecCoin{}, fmt.Errorf("%s: %w", coin.Denom, err)
+	}
+
+	units, err := r.GetBaseUnits(base)
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+	baseUnit, _ := r.GetDenomUnit(base)
+	unit := baseUnit.Mul(types.NewDecFromInt(types.NewIntWithDecimal(1, exponent)))
+
+	for _, u := range units {
+		if u.Exponent != exponent {
+			continue
+		}
+		if denomUnit, ok := r.GetDenomUnit(u.Denom); ok && denomUnit.Equal(unit) {
+			return r.ConvertDecCoin(types.NewDecCoinFromCoin(coin), u.Denom)
+		}
+	}
+
+	return types.DecCoin{}, fmt.Errorf("no denom registered at exponent %d over %s", exponent, base)
+}
+
+// RoundDecCoinToDisplay rounds a decimal coin to the precision its denom can
+// represent in its base, i.e. to the exponent of GetDenomInfo, e.g. 6 decimal
+// places for atom over uatom. A denom given by its display alias is resolved
+// to the canonical denom. An error is returned for unregistered denoms.
+func (r *Registry) RoundDecCoinToDisplay(coin types.DecCoin) (types.DecCoin, error) {
+	denom, err := r.resolveDenomAlias(coin.Denom)
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+
+	info, err := r.GetDenomInfo(denom)
+	if err != nil {
+		return types.DecCoin{}, err
+	}
+
+	scale := powerOfTen(info.Exponent)
+	amount := types.NewDecFromInt(coin.Amount.Mul(scale).RoundInt()).Quo(scale)
+	return types.DecCoin{Denom: denom, Amount: amount}, nil
+}
+
+// ConvertDecCoinChecked converts a decimal coin like ConvertDecCoin and also
+// reports whether a non-zero amount underflowed to zero in the conversion.
+func (r *Registry) ConvertDecCoinChecked(coin types.DecCoin, denom string) (types.DecCoin, bool, error) {
+	newCoin, err := r.ConvertDecCoin(coin, denom)
+	if err != nil {
+		return types.DecCoin{}, false, err
 	}
 
-	return types.NewDecCoinFromDec(denom, coin.Amount.Mul(srcUnit).Quo(dstUnit)), nil
+	underflow := !coin.Amount.IsZero() && newCoin.Amount.IsZero()
+	return newCoin, underflow, nil
 }
 
 // NormalizeCoin try to convert a coin to the smallest unit registered,
 // returns original one if failed.
-func NormalizeCoin(coin types.Coin) types.Coin {
-	base, err := GetBaseDenom(coin.Denom)
+func (r *Registry) NormalizeCoin(coin types.Coin) types.Coin {
+	base, err := r.cachedBaseDenom(coin.Denom)
 	if err != nil {
 		return coin
 	}
-	newCoin, err := ConvertCoin(coin, base)
+	newCoin, err := r.ConvertCoin(coin, base)
 	if err != nil {
 		return coin
 	}
@@ -121,12 +1664,12 @@ func NormalizeCoin(coin types.Coin) types.Coin {
 
 // NormalizeDecCoin try to convert a decimal coin to the smallest unit registered,
 // returns original one if failed.
-func NormalizeDecCoin(coin types.DecCoin) types.DecCoin {
-	base, err := GetBaseDenom(coin.Denom)
+func (r *Registry) NormalizeDecCoin(coin types.DecCoin) types.DecCoin {
+	base, err := r.cachedBaseDenom(coin.Denom)
 	if err != nil {
 		return coin
 	}
-	newCoin, err := ConvertDecCoin(coin, base)
+	newCoin, err := r.ConvertDecCoin(coin, base)
 	if err != nil {
 		return coin
 	}
@@ -134,36 +1677,141 @@ func NormalizeDecCoin(coin types.DecCoin) types.DecCoin {
 }
 
 // NormalizeCoins normalize and truncate a list of decimal coins
-func NormalizeCoins(coins []types.DecCoin) types.Coins {
+func (r *Registry) NormalizeCoins(coins []types.DecCoin) types.Coins {
 	if coins == nil {
 		return nil
 	}
 	result := make([]types.Coin, 0, len(coins))
 
 	for _, coin := range coins {
-		newCoin, _ := NormalizeDecCoin(coin).TruncateDecimal()
+		newCoin, _ := r.NormalizeDecCoin(coin).TruncateDecimal()
 		result = append(result, newCoin)
 	}
 
 	return result
 }
 
+// AddCoinNormalized normalizes both coins to their common base and returns
+// a + b in the base denom. An error is returned if the coins don't share a base.
+func (r *Registry) AddCoinNormalized(a, b types.Coin) (types.Coin, error) {
+	a, b = r.NormalizeCoin(a), r.NormalizeCoin(b)
+	if a.Denom != b.Denom {
+		return types.Coin{}, fmt.Errorf("coins %s and %s don't share a base denom", a, b)
+	}
+
+	return a.Add(b), nil
+}
+
+// SubtractCoinNormalized normalizes both coins to their common base and returns
+// a - b in the base denom. An error is returned if the coins don't share a base
+// or if the result would be negative.
+func (r *Registry) SubtractCoinNormalized(a, b types.Coin) (types.Coin, error) {
+	a, b = r.NormalizeCoin(a), r.NormalizeCoin(b)
+	if a.Denom != b.Denom {
+		return types.Coin{}, fmt.Errorf("coins %s and %s don't share a base denom", a, b)
+	}
+
+	if a.Amount.LT(b.Amount) {
+		return types.Coin{}, fmt.Errorf("negative result subtracting %s from %s", b, a)
+	}
+
+	return types.NewCoin(a.Denom, a.Amount.Sub(b.Amount)), nil
+}
+
 // ParseCoinNormalized parses and normalize a cli input for one coin type, returning errors if invalid or on an empty string
 // as well.
 // Expected format: "{amount}{denomination}"
-func ParseCoinNormalized(coinStr string) (coin types.Coin, err error) {
+func (r *Registry) ParseCoinNormalized(coinStr string) (coin types.Coin, err error) {
 	decCoin, err := types.ParseDecCoin(coinStr)
 	if err != nil {
 		return types.Coin{}, err
 	}
 
-	coin, _ = NormalizeDecCoin(decCoin).TruncateDecimal()
+	coin, _ = r.NormalizeDecCoin(decCoin).TruncateDecimal()
 	return coin, nil
 }
-func ParseCoinsNormalized(coinStr string) (types.Coins, error) {
+
+// ParseCoinsNormalized parses and normalizes a list of coins, returning them
+// sorted and without zero amounts. Inputs with more than maxParsedCoins coins,
+// with duplicate denoms or with denoms normalizing to the same base denom are
+// rejected, as are amounts overflowing when normalized.
+func (r *Registry) ParseCoinsNormalized(coinStr string) (types.Coins, error) {
+	if n := strings.Count(coinStr, ",") + 1; n > maxParsedCoins {
+		return types.Coins{}, fmt.Errorf("too many coins: %d > %d", n, maxParsedCoins)
+	}
+
 	coins, err := types.ParseDecCoins(coinStr)
 	if err != nil {
 		return types.Coins{}, err
 	}
-	return NormalizeCoins(coins), nil
+	if coins == nil {
+		return nil, nil
+	}
+
+	result := make(types.Coins, 0, len(coins))
+	denoms := make(map[string]string, len(coins))
+	for _, coin := range coins {
+		newCoin, err := r.normalizeDecCoinChecked(coin)
+		if err != nil {
+			return types.Coins{}, err
+		}
+
+		if other, ok := denoms[newCoin.Denom]; ok {
+			return types.Coins{}, fmt.Errorf("denoms %s and %s both normalize to %s", other, coin.Denom, newCoin.Denom)
+		}
+		denoms[newCoin.Denom] = coin.Denom
+
+		result = append(result, newCoin)
+	}
+
+	// normalizing reorders denoms and may truncate amounts to zero
+	return types.NewCoins(result...), nil
+}
+
+// ValidateCoinStrings parses and normalizes every input like
+// ParseCoinsNormalized, returning the error of each input at its index, nil
+// for valid ones, so that a whole batch of inputs can be reported at once.
+func (r *Registry) ValidateCoinStrings(strs []string) []error {
+	errs := make([]error, len(strs))
+	for i, str := range strs {
+		_, errs[i] = r.ParseCoinsNormalized(str)
+	}
+	return errs
+}
+
+// normalizeDecCoinChecked normalizes and truncates a decimal coin like
+// NormalizeCoins, returning an error instead of panicking when the normalized
+// amount overflows.
+func (r *Registry) normalizeDecCoinChecked(coin types.DecCoin) (types.Coin, error) {
+	if r.normalizedDecOverflows(coin) {
+		return types.Coin{}, fmt.Errorf("normalizing %s overflows the amount", coin)
+	}
+
+	newCoin, _ := r.NormalizeDecCoin(coin).TruncateDecimal()
+	return newCoin, nil
+}
+
+// normalizedDecOverflows returns if normalizing a decimal coin would exceed
+// maxDecBitLen. ConvertDecCoin computes amount * srcUnit / dstUnit as two
+// decimal operations, each of which panics above that bound. Coins that
+// NormalizeDecCoin leaves unconverted never overflow.
+func (r *Registry) normalizedDecOverflows(coin types.DecCoin) bool {
+	base, err := r.cachedBaseDenom(coin.Denom)
+	if err != nil {
+		return false
+	}
+	srcUnit, ok := r.GetDenomUnit(coin.Denom)
+	if !ok {
+		return false
+	}
+	dstUnit, ok := r.GetDenomUnit(base)
+	if !ok {
+		return false
+	}
+
+	// decimals are integers scaled by 10^Precision
+	product := new(big.Int).Mul(coin.Amount.BigInt(), srcUnit.BigInt())
+	mul := new(big.Int).Quo(product, types.OneDec().BigInt())
+	quo := new(big.Int).Quo(product, dstUnit.BigInt())
+	return mul.BitLen() > maxDecBitLen || quo.BitLen() > maxDecBitLen
 }
@@ -0,0 +1,293 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// The functions below operate on the default registry, see Registry for their
+// documentation.
+
+// RegisterDenom calls Registry.RegisterDenom on the default registry.
+func RegisterDenom(denom string, unit types.Dec, bDenom string, bUnit types.Dec) error {
+	return defaultRegistry.RegisterDenom(denom, unit, bDenom, bUnit)
+}
+
+// UpdateDenomUnit calls Registry.UpdateDenomUnit on the default registry.
+func UpdateDenomUnit(denom string, newUnit types.Dec) error {
+	return defaultRegistry.UpdateDenomUnit(denom, newUnit)
+}
+
+// DeregisterDenom calls Registry.DeregisterDenom on the default registry.
+func DeregisterDenom(denom string) error {
+	return defaultRegistry.DeregisterDenom(denom)
+}
+
+// SubscribeDenomChanges calls Registry.SubscribeDenomChanges on the default registry.
+func SubscribeDenomChanges() (<-chan DenomChangeEvent, func()) {
+	return defaultRegistry.SubscribeDenomChanges()
+}
+
+// RegisterDenomsFromString calls Registry.RegisterDenomsFromString on the default registry.
+func RegisterDenomsFromString(spec string) error {
+	return defaultRegistry.RegisterDenomsFromString(spec)
+}
+
+// LockDenomRegistry calls Registry.LockDenomRegistry on the default registry.
+func LockDenomRegistry() {
+	defaultRegistry.LockDenomRegistry()
+}
+
+// IsDenomRegistryLocked calls Registry.IsDenomRegistryLocked on the default registry.
+func IsDenomRegistryLocked() bool {
+	return defaultRegistry.IsDenomRegistryLocked()
+}
+
+// RegisterDenomWithAlias calls Registry.RegisterDenomWithAlias on the default registry.
+func RegisterDenomWithAlias(denom, alias string, unit types.Dec, bDenom string, bUnit types.Dec) error {
+	return defaultRegistry.RegisterDenomWithAlias(denom, alias, unit, bDenom, bUnit)
+}
+
+// GetDenomAlias calls Registry.GetDenomAlias on the default registry.
+func GetDenomAlias(denom string) (string, bool) {
+	return defaultRegistry.GetDenomAlias(denom)
+}
+
+// DeprecateDenom calls Registry.DeprecateDenom on the default registry.
+func DeprecateDenom(denom string) error {
+	return defaultRegistry.DeprecateDenom(denom)
+}
+
+// IsDenomDeprecated calls Registry.IsDenomDeprecated on the default registry.
+func IsDenomDeprecated(denom string) bool {
+	return defaultRegistry.IsDenomDeprecated(denom)
+}
+
+// SetDenomDustThreshold calls Registry.SetDenomDustThreshold on the default registry.
+func SetDenomDustThreshold(denom string, threshold types.Int) error {
+	return defaultRegistry.SetDenomDustThreshold(denom, threshold)
+}
+
+// SetNativeDenom calls Registry.SetNativeDenom on the default registry.
+func SetNativeDenom(denom string) error {
+	return defaultRegistry.SetNativeDenom(denom)
+}
+
+// GetNativeDenom calls Registry.GetNativeDenom on the default registry.
+func GetNativeDenom() (string, error) {
+	return defaultRegistry.GetNativeDenom()
+}
+
+// SetCaseInsensitiveDenomLookup calls Registry.SetCaseInsensitiveDenomLookup on the default registry.
+func SetCaseInsensitiveDenomLookup(enabled bool) {
+	defaultRegistry.SetCaseInsensitiveDenomLookup(enabled)
+}
+
+// GetDenomUnit calls Registry.GetDenomUnit on the default registry.
+func GetDenomUnit(denom string) (types.Dec, bool) {
+	return defaultRegistry.GetDenomUnit(denom)
+}
+
+// GetBaseDenom calls Registry.GetBaseDenom on the default registry.
+func GetBaseDenom(denom string) (string, error) {
+	return defaultRegistry.GetBaseDenom(denom)
+}
+
+// GetDenomInfo calls Registry.GetDenomInfo on the default registry.
+func GetDenomInfo(denom string) (DenomInfo, error) {
+	return defaultRegistry.GetDenomInfo(denom)
+}
+
+// DenomStep calls Registry.DenomStep on the default registry.
+func DenomStep(denom string) (types.Dec, error) {
+	return defaultRegistry.DenomStep(denom)
+}
+
+// GetBaseUnits calls Registry.GetBaseUnits on the default registry.
+func GetBaseUnits(bDenom string) ([]DenomUnit, error) {
+	return defaultRegistry.GetBaseUnits(bDenom)
+}
+
+// DisplayDenomsByMagnitude calls Registry.DisplayDenomsByMagnitude on the default registry.
+func DisplayDenomsByMagnitude(bDenom string) ([]string, error) {
+	return defaultRegistry.DisplayDenomsByMagnitude(bDenom)
+}
+
+// DenomGraph calls Registry.DenomGraph on the default registry.
+func DenomGraph() []DenomEdge {
+	return defaultRegistry.DenomGraph()
+}
+
+// CommonBaseDenom calls Registry.CommonBaseDenom on the default registry.
+func CommonBaseDenom(coins types.Coins) (string, error) {
+	return defaultRegistry.CommonBaseDenom(coins)
+}
+
+// GetEquivalentDenoms calls Registry.GetEquivalentDenoms on the default registry.
+func GetEquivalentDenoms(denom string) []string {
+	return defaultRegistry.GetEquivalentDenoms(denom)
+}
+
+// ListBaseDenoms calls Registry.ListBaseDenoms on the default registry.
+func ListBaseDenoms() []string {
+	return defaultRegistry.ListBaseDenoms()
+}
+
+// RegisteredDenomCount calls Registry.RegisteredDenomCount on the default registry.
+func RegisteredDenomCount() int {
+	return defaultRegistry.RegisteredDenomCount()
+}
+
+// ValidateDenomRegistry calls Registry.ValidateDenomRegistry on the default registry.
+func ValidateDenomRegistry() error {
+	return defaultRegistry.ValidateDenomRegistry()
+}
+
+// ListRegisteredDenoms calls Registry.ListRegisteredDenoms on the default registry.
+func ListRegisteredDenoms() []DenomRegistryEntry {
+	return defaultRegistry.ListRegisteredDenoms()
+}
+
+// MarshalDenomRegistryJSON calls Registry.MarshalDenomRegistryJSON on the default registry.
+func MarshalDenomRegistryJSON() ([]byte, error) {
+	return defaultRegistry.MarshalDenomRegistryJSON()
+}
+
+// AreConvertible calls Registry.AreConvertible on the default registry.
+func AreConvertible(fromDenom, toDenom string) bool {
+	return defaultRegistry.AreConvertible(fromDenom, toDenom)
+}
+
+// ConvertCoin calls Registry.ConvertCoin on the default registry.
+func ConvertCoin(coin types.Coin, denom string) (types.Coin, error) {
+	return defaultRegistry.ConvertCoin(coin, denom)
+}
+
+// ExponentDelta calls Registry.ExponentDelta on the default registry.
+func ExponentDelta(fromDenom, toDenom string) (int, error) {
+	return defaultRegistry.ExponentDelta(fromDenom, toDenom)
+}
+
+// ConvertCoinSafe calls Registry.ConvertCoinSafe on the default registry.
+func ConvertCoinSafe(coin types.Coin, denom string) (types.Coin, error) {
+	return defaultRegistry.ConvertCoinSafe(coin, denom)
+}
+
+// ConvertCoinDustAware calls Registry.ConvertCoinDustAware on the default registry.
+func ConvertCoinDustAware(coin types.Coin, denom string) (types.Coin, error) {
+	return defaultRegistry.ConvertCoinDustAware(coin, denom)
+}
+
+// ConvertCoinMaxLoss calls Registry.ConvertCoinMaxLoss on the default registry.
+func ConvertCoinMaxLoss(coin types.Coin, denom string, maxLoss types.Int) (types.Coin, error) {
+	return defaultRegistry.ConvertCoinMaxLoss(coin, denom, maxLoss)
+}
+
+// ConvertCoinBounds calls Registry.ConvertCoinBounds on the default registry.
+func ConvertCoinBounds(coin types.Coin, denom string) (floor, ceil types.Coin, err error) {
+	return defaultRegistry.ConvertCoinBounds(coin, denom)
+}
+
+// ConvertCoinsBestEffort calls Registry.ConvertCoinsBestEffort on the default registry.
+func ConvertCoinsBestEffort(coins types.Coins, denom string) types.Coins {
+	return defaultRegistry.ConvertCoinsBestEffort(coins, denom)
+}
+
+// TotalInNative calls Registry.TotalInNative on the default registry.
+func TotalInNative(coins types.Coins) (types.Coin, error) {
+	return defaultRegistry.TotalInNative(coins)
+}
+
+// RegisterCrossRate calls Registry.RegisterCrossRate on the default registry.
+func RegisterCrossRate(baseA, baseB string, rate types.Dec) error {
+	return defaultRegistry.RegisterCrossRate(baseA, baseB, rate)
+}
+
+// ExplainConvertCoin calls Registry.ExplainConvertCoin on the default registry.
+func ExplainConvertCoin(coin types.Coin, denom string) (string, error) {
+	return defaultRegistry.ExplainConvertCoin(coin, denom)
+}
+
+// ConvertCoinCrossBase calls Registry.ConvertCoinCrossBase on the default registry.
+func ConvertCoinCrossBase(coin types.Coin, denom string) (types.Coin, error) {
+	return defaultRegistry.ConvertCoinCrossBase(coin, denom)
+}
+
+// ConvertCoinToDec calls Registry.ConvertCoinToDec on the default registry.
+func ConvertCoinToDec(coin types.Coin, denom string) (types.DecCoin, error) {
+	return defaultRegistry.ConvertCoinToDec(coin, denom)
+}
+
+// ConvertToBestDisplay calls Registry.ConvertToBestDisplay on the default registry.
+func ConvertToBestDisplay(coin types.Coin) (types.Coin, error) {
+	return defaultRegistry.ConvertToBestDisplay(coin)
+}
+
+// FormatCoins calls Registry.FormatCoins on the default registry.
+func FormatCoins(coins types.Coins) string {
+	return defaultRegistry.FormatCoins(coins)
+}
+
+// ConvertCoinSigFigs calls Registry.ConvertCoinSigFigs on the default registry.
+func ConvertCoinSigFigs(coin types.Coin, denom string, sigFigs int) (types.DecCoin, error) {
+	return defaultRegistry.ConvertCoinSigFigs(coin, denom, sigFigs)
+}
+
+// ConvertDecCoin calls Registry.ConvertDecCoin on the default registry.
+func ConvertDecCoin(coin types.DecCoin, denom string) (types.DecCoin, error) {
+	return defaultRegistry.ConvertDecCoin(coin, denom)
+}
+
+// ConvertCoinToExponent calls Registry.ConvertCoinToExponent on the default registry.
+func ConvertCoinToExponent(coin types.Coin, exponent int) (types.DecCoin, error) {
+	return defaultRegistry.ConvertCoinToExponent(coin, exponent)
+}
+
+// RoundDecCoinToDisplay calls Registry.RoundDecCoinToDisplay on the default registry.
+func RoundDecCoinToDisplay(coin types.DecCoin) (types.DecCoin, error) {
+	return defaultRegistry.RoundDecCoinToDisplay(coin)
+}
+
+// ConvertDecCoinChecked calls Registry.ConvertDecCoinChecked on the default registry.
+func ConvertDecCoinChecked(coin types.DecCoin, denom string) (types.DecCoin, bool, error) {
+	return defaultRegistry.ConvertDecCoinChecked(coin, denom)
+}
+
+// NormalizeCoin calls Registry.NormalizeCoin on the default registry.
+func NormalizeCoin(coin types.Coin) types.Coin {
+	return defaultRegistry.NormalizeCoin(coin)
+}
+
+// NormalizeDecCoin calls Registry.NormalizeDecCoin on the default registry.
+func NormalizeDecCoin(coin types.DecCoin) types.DecCoin {
+	return defaultRegistry.NormalizeDecCoin(coin)
+}
+
+// NormalizeCoins calls Registry.NormalizeCoins on the default registry.
+func NormalizeCoins(coins []types.DecCoin) types.Coins {
+	return defaultRegistry.NormalizeCoins(coins)
+}
+
+// AddCoinNormalized calls Registry.AddCoinNormalized on the default registry.
+func AddCoinNormalized(a, b types.Coin) (types.Coin, error) {
+	return defaultRegistry.AddCoinNormalized(a, b)
+}
+
+// SubtractCoinNormalized calls Registry.SubtractCoinNormalized on the default registry.
+func SubtractCoinNormalized(a, b types.Coin) (types.Coin, error) {
+	return defaultRegistry.SubtractCoinNormalized(a, b)
+}
+
+// ParseCoinNormalized calls Registry.ParseCoinNormalized on the default registry.
+func ParseCoinNormalized(coinStr string) (coin types.Coin, err error) {
+	return defaultRegistry.ParseCoinNormalized(coinStr)
+}
+
+// ParseCoinsNormalized calls Registry.ParseCoinsNormalized on the default registry.
+func ParseCoinsNormalized(coinStr string) (types.Coins, error) {
+	return defaultRegistry.ParseCoinsNormalized(coinStr)
+}
+
+// ValidateCoinStrings calls Registry.ValidateCoinStrings on the default registry.
+func ValidateCoinStrings(strs []string) []error {
+	return defaultRegistry.ValidateCoinStrings(strs)
+}
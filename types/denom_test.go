@@ -0,0 +1,1201 @@
+package types
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetDenomRegistry replaces the default registry so each test starts from an
+// empty state.
+func resetDenomRegistry() {
+	defaultRegistry = NewRegistry()
+}
+
+// registerAtom registers atom over uatom (1atom = 10^6uatom).
+func registerAtom(t *testing.T) {
+	require.NoError(t, RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+}
+
+func TestRegistryIsolation(t *testing.T) {
+	resetDenomRegistry()
+
+	// the same denom registered with conflicting units in each registry
+	a, b := NewRegistry(), NewRegistry()
+	require.NoError(t, a.RegisterDenom("gold", types.OneDec(), "mgold", types.NewDecWithPrec(1, 3)))
+	require.NoError(t, b.RegisterDenom("gold", types.OneDec(), "ugold", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, b.RegisterDenom("mgold", types.NewDecWithPrec(1, 3), "ugold", types.NewDecWithPrec(1, 6)))
+
+	coin, err := a.ConvertCoin(types.NewInt64Coin("gold", 2), "mgold")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("mgold", 2000), coin)
+	_, err = a.ConvertCoin(types.NewInt64Coin("gold", 2), "ugold")
+	require.Error(t, err)
+
+	coin, err = b.ConvertCoin(types.NewInt64Coin("mgold", 2), "ugold")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("ugold", 2000), coin)
+	require.Equal(t, types.NewInt64Coin("ugold", 2000000), b.NormalizeCoin(types.NewInt64Coin("gold", 2)))
+	require.Equal(t, types.NewInt64Coin("mgold", 2000), a.NormalizeCoin(types.NewInt64Coin("gold", 2)))
+
+	// locking one registry leaves the others open
+	a.LockDenomRegistry()
+	require.Error(t, a.RegisterDenom("silver", types.OneDec(), "usilver", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, b.RegisterDenom("silver", types.OneDec(), "usilver", types.NewDecWithPrec(1, 6)))
+
+	// the default registry is untouched
+	require.Zero(t, RegisteredDenomCount())
+	_, err = ConvertCoin(types.NewInt64Coin("gold", 2), "mgold")
+	require.Error(t, err)
+}
+
+func TestRegisterDenomsFromString(t *testing.T) {
+	resetDenomRegistry()
+
+	require.NoError(t, RegisterDenomsFromString("atom:uatom:6, btc:satoshi:8"))
+
+	info, err := GetDenomInfo("atom")
+	require.NoError(t, err)
+	require.Equal(t, "uatom", info.BaseDenom)
+	require.Equal(t, 6, info.Exponent)
+
+	info, err = GetDenomInfo("btc")
+	require.NoError(t, err)
+	require.Equal(t, "satoshi", info.BaseDenom)
+	require.Equal(t, 8, info.Exponent)
+
+	resetDenomRegistry()
+	for spec, msg := range map[string]string{
+		"atom:uatom:6,eth:wei":     `invalid denom entry "eth:wei": expected display:base:exponent`,
+		"atom:uatom:6,eth:wei:x":   `invalid denom entry "eth:wei:x": exponent must be an integer in [0, 18]`,
+		"atom:uatom:6,eth:wei:19":  `invalid denom entry "eth:wei:19": exponent must be an integer in [0, 18]`,
+		"atom:uatom:6,eth:w!ei:18": `invalid denom entry "eth:w!ei:18": invalid denom: w!ei`,
+	} {
+		require.EqualError(t, RegisterDenomsFromString(spec), msg)
+		// malformed specs register nothing
+		require.Zero(t, RegisteredDenomCount())
+	}
+
+	// entries sharing a base are scaled from the registered base unit
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenomsFromString("atom:uatom:6,matom:uatom:3"))
+	coin, err := ConvertCoin(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), coin)
+	coin, err = ConvertCoin(types.NewInt64Coin("matom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000), coin)
+
+	require.NoError(t, RegisterDenomsFromString("katom:uatom:9"))
+	coin, err = ConvertCoin(types.NewInt64Coin("katom", 1), "atom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 1000), coin)
+
+	// a failing entry registers none of the spec
+	resetDenomRegistry()
+	require.EqualError(t, RegisterDenomsFromString("atom:uatom:6,btc:satoshi:8,atom:uatom:3"),
+		"denom entry atom:uatom:3: denom atom already registered")
+	require.Zero(t, RegisteredDenomCount())
+}
+
+func TestLockDenomRegistry(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.False(t, IsDenomRegistryLocked())
+
+	LockDenomRegistry()
+	require.True(t, IsDenomRegistryLocked())
+
+	require.Error(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+	require.Error(t, RegisterDenomWithAlias("btc", "BTC", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+	_, ok := GetDenomUnit("btc")
+	require.False(t, ok)
+
+	// registrations made before the lock are kept
+	_, ok = GetDenomUnit("atom")
+	require.True(t, ok)
+}
+
+func TestRegistryConcurrentUse(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+
+	// run with -race: registrations interleave with conversions
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.NoError(t, r.RegisterDenom(fmt.Sprintf("denom%dx%d", i, j), types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				assert.Equal(t, types.NewInt64Coin("uatom", 1000000), r.NormalizeCoin(types.NewInt64Coin("atom", 1)))
+				_, err := r.GetBaseUnits("uatom")
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 201, r.RegisteredDenomCount())
+}
+
+func TestUpdateDenomUnit(t *testing.T) {
+	resetDenomRegistry()
+	// wrongly registered with 3 decimals instead of 6
+	require.NoError(t, RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 3)))
+
+	coin, err := ConvertCoin(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000), coin)
+
+	require.Error(t, UpdateDenomUnit("btc", types.OneDec()))
+	require.Error(t, UpdateDenomUnit("uatom", types.ZeroDec()))
+	require.Error(t, UpdateDenomUnit("uatom", types.NewDec(2)))
+	require.Error(t, UpdateDenomUnit("atom", types.NewDecWithPrec(1, 4)))
+
+	require.NoError(t, UpdateDenomUnit("uatom", types.NewDecWithPrec(1, 6)))
+	coin, err = ConvertCoin(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), coin)
+
+	// a denom registered as its own base keeps unit 1
+	require.NoError(t, RegisterDenom("gold", types.OneDec(), "gold", types.OneDec()))
+	require.Error(t, UpdateDenomUnit("gold", types.NewDecWithPrec(1, 3)))
+	require.NoError(t, UpdateDenomUnit("gold", types.OneDec()))
+
+	LockDenomRegistry()
+	require.Error(t, UpdateDenomUnit("uatom", types.NewDecWithPrec(1, 5)))
+}
+
+func TestDeregisterDenom(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenomWithAlias("atom", "ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, SetNativeDenom("atom"))
+
+	require.Error(t, DeregisterDenom("btc"))
+	require.Error(t, DeregisterDenom("uatom"))
+
+	require.NoError(t, DeregisterDenom("atom"))
+	_, ok := GetDenomUnit("atom")
+	require.False(t, ok)
+	_, ok = GetDenomAlias("atom")
+	require.False(t, ok)
+	_, err := GetNativeDenom()
+	require.Error(t, err)
+
+	// the base can go once nothing normalizes to it anymore
+	require.NoError(t, DeregisterDenom("uatom"))
+	require.Zero(t, RegisteredDenomCount())
+	require.Empty(t, ListBaseDenoms())
+}
+
+func TestSubscribeDenomChanges(t *testing.T) {
+	resetDenomRegistry()
+
+	events, unsubscribe := SubscribeDenomChanges()
+	registerAtom(t)
+	require.NoError(t, DeprecateDenom("atom"))
+	require.NoError(t, DeregisterDenom("atom"))
+
+	require.Equal(t, DenomChangeEvent{Kind: DenomRegistered, Denom: "atom"}, <-events)
+	require.Equal(t, DenomChangeEvent{Kind: DenomDeprecated, Denom: "atom"}, <-events)
+	require.Equal(t, DenomChangeEvent{Kind: DenomDeregistered, Denom: "atom"}, <-events)
+
+	// a consumer that never reads doesn't block registration
+	for i := 0; i < 2*denomChangeBuffer; i++ {
+		require.NoError(t, RegisterDenom(fmt.Sprintf("denom%d", i), types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+	}
+	require.Len(t, events, denomChangeBuffer)
+
+	unsubscribe()
+	unsubscribe()
+	for range events {
+	}
+	_, open := <-events
+	require.False(t, open)
+}
+
+func TestRegisterDenomSelfBaseUnit(t *testing.T) {
+	resetDenomRegistry()
+
+	err := RegisterDenom("stake", types.NewDec(10), "stake", types.NewDec(10))
+	require.EqualError(t, err, "denom stake registered as its own base must have unit 1, got 10.000000000000000000")
+	_, ok := GetDenomUnit("stake")
+	require.False(t, ok)
+
+	require.NoError(t, RegisterDenom("stake", types.OneDec(), "stake", types.OneDec()))
+}
+
+func TestRegisterDenomKeepsBaseUnit(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenom("uatom", types.OneDec(), "uatom", types.OneDec()))
+
+	err := RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6))
+	require.EqualError(t, err, "base denom uatom is registered with unit 1.000000000000000000, got 0.000001000000000000")
+	_, ok := GetDenomUnit("atom")
+	require.False(t, ok)
+	unit, _ := GetDenomUnit("uatom")
+	require.Equal(t, types.OneDec(), unit)
+
+	// denoms scaled relative to the registered base unit are accepted
+	require.NoError(t, RegisterDenom("atom", types.NewDec(1000000), "uatom", types.OneDec()))
+	coin, err := ConvertCoin(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), coin)
+}
+
+func TestConvertCoinSafe(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	_, err := ConvertCoinSafe(types.NewInt64Coin("uatom", 5), "atom")
+	require.Error(t, err)
+
+	// ConvertCoin keeps truncating silently
+	coin, err := ConvertCoin(types.NewInt64Coin("uatom", 5), "atom")
+	require.NoError(t, err)
+	require.True(t, coin.IsZero())
+
+	coin, err = ConvertCoinSafe(types.NewInt64Coin("uatom", 1500000), "atom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 1), coin)
+
+	coin, err = ConvertCoinSafe(types.NewInt64Coin("uatom", 0), "atom")
+	require.NoError(t, err)
+	require.True(t, coin.IsZero())
+}
+
+func TestListBaseDenoms(t *testing.T) {
+	resetDenomRegistry()
+	require.Empty(t, ListBaseDenoms())
+
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	require.Equal(t, []string{"satoshi", "uatom"}, ListBaseDenoms())
+}
+
+func TestValidateDenomRegistry(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, ValidateDenomRegistry())
+
+	defaultRegistry.denomUnits["matom"] = types.NewDecWithPrec(1, 3)
+	require.EqualError(t, ValidateDenomRegistry(), "denom matom has no base denom registered")
+
+	defaultRegistry.baseDenom["matom"] = "natom"
+	require.EqualError(t, ValidateDenomRegistry(), "base denom natom of matom is not registered")
+
+	defaultRegistry.denomUnits["natom"] = types.NewDecWithPrec(1, 9)
+	defaultRegistry.baseDenom["natom"] = "uatom"
+	require.EqualError(t, ValidateDenomRegistry(), "base denom natom of matom does not map onto itself")
+
+	defaultRegistry.baseDenom["matom"] = "uatom"
+	defaultRegistry.baseDenom["natom"] = "natom"
+	defaultRegistry.denomUnits["atom"] = types.ZeroDec()
+	require.EqualError(t, ValidateDenomRegistry(), "denom atom has non-positive unit 0.000000000000000000")
+}
+
+func TestConvertCoinsBestEffort(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	coins := types.NewCoins(
+		types.NewInt64Coin("atom", 2),
+		types.NewInt64Coin("stake", 7),
+		types.NewInt64Coin("uatom", 500000),
+	)
+
+	require.Equal(t, types.NewCoins(
+		types.NewInt64Coin("stake", 7),
+		types.NewInt64Coin("uatom", 2500000),
+	), ConvertCoinsBestEffort(coins, "uatom"))
+
+	// nothing converts to an unregistered denom
+	require.Equal(t, coins, ConvertCoinsBestEffort(coins, "stake"))
+}
+
+func TestCaseInsensitiveDenomLookup(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	_, ok := GetDenomUnit("ATOM")
+	require.False(t, ok)
+	_, err := GetBaseDenom("Atom")
+	require.Error(t, err)
+
+	SetCaseInsensitiveDenomLookup(true)
+	unit, ok := GetDenomUnit("ATOM")
+	require.True(t, ok)
+	require.Equal(t, types.OneDec(), unit)
+	base, err := GetBaseDenom("Atom")
+	require.NoError(t, err)
+	require.Equal(t, "uatom", base)
+
+	// conversions return the registered spelling, not the looked up one
+	coin, err := ConvertCoin(types.NewInt64Coin("uatom", 2000000), "ATOM")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 2), coin)
+	require.NoError(t, coin.Validate())
+
+	// toggling the lookup drops the resolutions memoized under the old mode
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), NormalizeCoin(types.NewInt64Coin("ATOM", 1)))
+	require.Equal(t, "uatom", defaultRegistry.baseDenomCache["ATOM"])
+	require.NotEmpty(t, defaultRegistry.exponentDeltaCache)
+
+	SetCaseInsensitiveDenomLookup(false)
+	_, ok = GetDenomUnit("ATOM")
+	require.False(t, ok)
+	require.Empty(t, defaultRegistry.baseDenomCache)
+	require.Empty(t, defaultRegistry.exponentDeltaCache)
+	require.Equal(t, types.NewInt64Coin("ATOM", 1), NormalizeCoin(types.NewInt64Coin("ATOM", 1)))
+}
+
+func TestExplainConvertCoin(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	explanation, err := ExplainConvertCoin(types.NewInt64Coin("uatom", 1500000), "atom")
+	require.NoError(t, err)
+	require.Equal(t, "1500000 uatom × 1e-6 ÷ 1 = 1.5 atom (truncated to 1 atom)", explanation)
+
+	explanation, err = ExplainConvertCoin(types.NewInt64Coin("atom", 2), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, "2 atom × 1 ÷ 1e-6 = 2000000 uatom (exact)", explanation)
+
+	_, err = ExplainConvertCoin(types.NewInt64Coin("uatom", 1), "btc")
+	require.Error(t, err)
+}
+
+func TestAreConvertible(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenomWithAlias("atom", "ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	require.True(t, AreConvertible("atom", "uatom"))
+	require.True(t, AreConvertible("ATOM", "uatom"))
+	require.True(t, AreConvertible("uatom", "uatom"))
+
+	require.False(t, AreConvertible("atom", "eth"))
+	require.False(t, AreConvertible("eth", "atom"))
+
+	require.False(t, AreConvertible("atom", "btc"))
+	require.NoError(t, RegisterCrossRate("uatom", "satoshi", types.NewDecWithPrec(5, 1)))
+	require.True(t, AreConvertible("atom", "btc"))
+	require.True(t, AreConvertible("satoshi", "ATOM"))
+}
+
+func TestConvertCoinCrossBase(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	_, err := ConvertCoinCrossBase(types.NewInt64Coin("atom", 1), "btc")
+	require.Error(t, err)
+
+	require.Error(t, RegisterCrossRate("atom", "satoshi", types.OneDec()))
+	require.Error(t, RegisterCrossRate("uatom", "uatom", types.OneDec()))
+	require.Error(t, RegisterCrossRate("uatom", "satoshi", types.ZeroDec()))
+
+	// 1uatom = 0.5satoshi, i.e. 1atom = 0.005btc
+	require.NoError(t, RegisterCrossRate("uatom", "satoshi", types.NewDecWithPrec(5, 1)))
+
+	coin, err := ConvertCoinCrossBase(types.NewInt64Coin("atom", 3), "satoshi")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("satoshi", 1500000), coin)
+
+	coin, err = ConvertCoinCrossBase(types.NewInt64Coin("btc", 1), "atom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 200), coin)
+
+	// same base conversions don't need a cross rate
+	coin, err = ConvertCoinCrossBase(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), coin)
+}
+
+func TestConvertCoinToDec(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	coin, err := ConvertCoinToDec(types.NewInt64Coin("uatom", 5), "atom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewDecCoinFromDec("atom", types.NewDecWithPrec(5, 6)), coin)
+
+	_, err = ConvertCoinToDec(types.NewInt64Coin("uatom", 5), "btc")
+	require.Error(t, err)
+}
+
+func TestConvertToBestDisplay(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+
+	for _, tc := range []struct {
+		coin     types.Coin
+		expected types.Coin
+	}{
+		{types.NewInt64Coin("uatom", 2500000), types.NewInt64Coin("atom", 2)},
+		{types.NewInt64Coin("uatom", 1000), types.NewInt64Coin("matom", 1)},
+		{types.NewInt64Coin("uatom", 999), types.NewInt64Coin("uatom", 999)},
+		{types.NewInt64Coin("matom", 5), types.NewInt64Coin("matom", 5)},
+		{types.NewInt64Coin("uatom", 0), types.NewInt64Coin("uatom", 0)},
+	} {
+		coin, err := ConvertToBestDisplay(tc.coin)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, coin, tc.coin.String())
+	}
+
+	_, err := ConvertToBestDisplay(types.NewInt64Coin("btc", 1))
+	require.Error(t, err)
+}
+
+func TestFormatCoins(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	require.Equal(t, "", FormatCoins(types.Coins{}))
+	require.Equal(t, "1.5 atom, 2.5 btc", FormatCoins(types.NewCoins(
+		types.NewInt64Coin("uatom", 1500000), types.NewInt64Coin("satoshi", 250000000),
+	)))
+
+	// coins are listed by display denom, unregistered ones raw
+	require.NoError(t, RegisterDenom("cbtc", types.NewDecWithPrec(1, 2), "satoshi", types.NewDecWithPrec(1, 8)))
+	require.Equal(t, "2 atom, 2.5 cbtc, 5foo", FormatCoins(types.NewCoins(
+		types.NewInt64Coin("uatom", 2000000), types.NewInt64Coin("foo", 5), types.NewInt64Coin("satoshi", 2500000),
+	)))
+}
+
+func TestExponentDelta(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("katom", types.NewDec(1000), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("qatom", types.NewDecWithPrec(25, 2), "uatom", types.NewDecWithPrec(1, 6)))
+
+	for _, tc := range []struct {
+		from, to string
+		expected int
+	}{
+		{"uatom", "atom", 6},
+		{"atom", "uatom", -6},
+		{"matom", "katom", 6},
+		{"atom", "atom", 0},
+	} {
+		delta, err := ExponentDelta(tc.from, tc.to)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, delta, tc.from+"->"+tc.to)
+	}
+
+	_, err := ExponentDelta("qatom", "atom")
+	require.Error(t, err)
+	_, err = ExponentDelta("uatom", "btc")
+	require.Error(t, err)
+	_, err = ExponentDelta("btc", "uatom")
+	require.Error(t, err)
+}
+
+func TestConvertCoinWithRatio(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	// registered units are ignored
+	coin := ConvertCoinWithRatio(types.NewInt64Coin("uatom", 1000), "atom", types.MustNewDecFromStr("0.0333"))
+	require.Equal(t, types.NewInt64Coin("atom", 33), coin)
+
+	// neither denom needs to be registered
+	coin = ConvertCoinWithRatio(types.NewInt64Coin("foo", 7), "bar", types.NewDecWithPrec(15, 1))
+	require.Equal(t, types.NewInt64Coin("bar", 10), coin)
+}
+
+func TestConvertCoinSigFigs(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	for _, tc := range []struct {
+		amount   int64
+		sigFigs  int
+		expected string
+	}{
+		{1234567, 3, "1.230000000000000000"},
+		{1235567, 3, "1.240000000000000000"},
+		{987654321, 2, "990.000000000000000000"},
+		{5, 3, "0.000005000000000000"},
+		{0, 3, "0.000000000000000000"},
+	} {
+		coin, err := ConvertCoinSigFigs(types.NewInt64Coin("uatom", tc.amount), "atom", tc.sigFigs)
+		require.NoError(t, err)
+		require.Equal(t, "atom", coin.Denom)
+		require.Equal(t, tc.expected, coin.Amount.String(), tc.amount)
+	}
+
+	_, err := ConvertCoinSigFigs(types.NewInt64Coin("uatom", 1), "btc", 3)
+	require.Error(t, err)
+	_, err = ConvertCoinSigFigs(types.NewInt64Coin("uatom", 1), "atom", 0)
+	require.Error(t, err)
+}
+
+func TestTotalInNative(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	coins := types.NewCoins(types.NewInt64Coin("atom", 2), types.NewInt64Coin("uatom", 500000))
+
+	_, err := TotalInNative(coins)
+	require.Error(t, err)
+
+	require.NoError(t, SetNativeDenom("uatom"))
+	total, err := TotalInNative(coins)
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 2500000), total)
+
+	_, err = TotalInNative(coins.Add(types.NewInt64Coin("stake", 7)))
+	require.Error(t, err)
+}
+
+func TestConvertCoinBounds(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	floor, ceil, err := ConvertCoinBounds(types.NewInt64Coin("uatom", 1500000), "atom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 1), floor)
+	require.Equal(t, floor.AddAmount(types.OneInt()), ceil)
+
+	floor, ceil, err = ConvertCoinBounds(types.NewInt64Coin("atom", 2), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 2000000), floor)
+	require.Equal(t, floor, ceil)
+
+	_, _, err = ConvertCoinBounds(types.NewInt64Coin("stake", 1), "atom")
+	require.Error(t, err)
+
+	// aliases resolve to the canonical denoms
+	require.NoError(t, RegisterDenomWithAlias("btc", "BTC", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+	floor, ceil, err = ConvertCoinBounds(types.NewInt64Coin("BTC", 2), "satoshi")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("satoshi", 200000000), floor)
+	require.Equal(t, floor, ceil)
+	floor, _, err = ConvertCoinBounds(types.NewInt64Coin("satoshi", 150000000), "BTC")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("btc", 1), floor)
+}
+
+func TestAddCoinNormalized(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	coin, err := AddCoinNormalized(types.NewInt64Coin("atom", 1), types.NewInt64Coin("uatom", 500000))
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1500000), coin)
+
+	_, err = AddCoinNormalized(types.NewInt64Coin("atom", 1), types.NewInt64Coin("btc", 1))
+	require.Error(t, err)
+}
+
+func TestSubtractCoinNormalized(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	coin, err := SubtractCoinNormalized(types.NewInt64Coin("atom", 2), types.NewInt64Coin("uatom", 1000000))
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), coin)
+
+	_, err = SubtractCoinNormalized(types.NewInt64Coin("uatom", 1000000), types.NewInt64Coin("atom", 2))
+	require.Error(t, err)
+
+	_, err = SubtractCoinNormalized(types.NewInt64Coin("atom", 2), types.NewInt64Coin("btc", 1))
+	require.Error(t, err)
+}
+
+func TestMarshalDenomRegistryJSON(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	bz, err := MarshalDenomRegistryJSON()
+	require.NoError(t, err)
+	require.Equal(t, `[{"denom":"atom","base":"uatom","unit":"1.000000000000000000"},`+
+		`{"denom":"btc","base":"satoshi","unit":"1.000000000000000000"},`+
+		`{"denom":"satoshi","base":"satoshi","unit":"0.000000010000000000"},`+
+		`{"denom":"uatom","base":"uatom","unit":"0.000001000000000000"}]`, string(bz))
+
+	for i := 0; i < 10; i++ {
+		again, err := MarshalDenomRegistryJSON()
+		require.NoError(t, err)
+		require.Equal(t, bz, again)
+	}
+}
+
+func TestRegisterDenomWithAlias(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenomWithAlias("atom", "ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+
+	alias, ok := GetDenomAlias("atom")
+	require.True(t, ok)
+	require.Equal(t, "ATOM", alias)
+
+	_, ok = GetDenomAlias("uatom")
+	require.False(t, ok)
+
+	err := RegisterDenomWithAlias("atom2", "ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6))
+	require.EqualError(t, err, "alias ATOM already registered for denom atom")
+	_, registered := GetDenomUnit("atom2")
+	require.False(t, registered)
+
+	require.Error(t, RegisterDenomWithAlias("btc", "", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	// aliases can't shadow denoms or be invalid denoms
+	for alias, msg := range map[string]string{
+		"uatom":   "alias uatom of denom btc is a registered denom",
+		"satoshi": "alias satoshi of denom btc is a registered denom",
+		"btc":     "alias btc of denom btc is a registered denom",
+		"B!TC":    "invalid alias of denom btc: invalid denom: B!TC",
+	} {
+		require.EqualError(t, RegisterDenomWithAlias("btc", alias, types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)), msg)
+		_, registered := GetDenomUnit("btc")
+		require.False(t, registered)
+	}
+	_, err = ConvertCoin(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+}
+
+func TestConvertCoinAlias(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenomWithAlias("atom", "ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+
+	canonical, err := ConvertCoin(types.NewInt64Coin("atom", 3), "uatom")
+	require.NoError(t, err)
+	aliased, err := ConvertCoin(types.NewInt64Coin("ATOM", 3), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, canonical, aliased)
+
+	coin, err := ConvertCoin(types.NewInt64Coin("uatom", 3000000), "ATOM")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 3), coin)
+
+	// an alias shadowed by a registered denom is ambiguous
+	require.NoError(t, RegisterDenom("ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+	_, err = ConvertCoin(types.NewInt64Coin("ATOM", 3), "uatom")
+	require.Error(t, err)
+	_, err = ConvertCoin(types.NewInt64Coin("uatom", 3), "ATOM")
+	require.Error(t, err)
+}
+
+func TestNormalizeCoinCacheInvalidation(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), NormalizeCoin(types.NewInt64Coin("atom", 1)))
+	require.Equal(t, "uatom", defaultRegistry.baseDenomCache["atom"])
+
+	// unregistered denoms are not cached
+	require.Equal(t, types.NewInt64Coin("stake", 1), NormalizeCoin(types.NewInt64Coin("stake", 1)))
+	_, ok := defaultRegistry.baseDenomCache["stake"]
+	require.False(t, ok)
+
+	// registering atom as the base of another denom must not serve the
+	// memoized uatom base anymore
+	require.NoError(t, RegisterDenom("katom", types.NewDec(1000), "atom", types.OneDec()))
+	require.Equal(t, types.NewInt64Coin("atom", 1), NormalizeCoin(types.NewInt64Coin("atom", 1)))
+	require.Equal(t, types.NewInt64DecCoin("atom", 1000), NormalizeDecCoin(types.NewInt64DecCoin("katom", 1)))
+}
+
+func BenchmarkNormalizeCoin(b *testing.B) {
+	resetDenomRegistry()
+	if err := RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)); err != nil {
+		b.Fatal(err)
+	}
+	coin := types.NewInt64Coin("atom", 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NormalizeCoin(coin)
+	}
+}
+
+func TestConvertDecCoinChecked(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("katom", types.NewDec(1000), "uatom", types.NewDecWithPrec(1, 6)))
+
+	coin, underflow, err := ConvertDecCoinChecked(types.NewInt64DecCoin("atom", 5), "katom")
+	require.NoError(t, err)
+	require.False(t, underflow)
+	require.Equal(t, types.NewDecCoinFromDec("katom", types.NewDecWithPrec(5, 3)), coin)
+
+	// 10^-18 uatom is 10^-27 katom, below Dec precision
+	coin, underflow, err = ConvertDecCoinChecked(types.NewDecCoinFromDec("uatom", types.SmallestDec()), "katom")
+	require.NoError(t, err)
+	require.True(t, underflow)
+	require.True(t, coin.Amount.IsZero())
+
+	_, underflow, err = ConvertDecCoinChecked(types.NewInt64DecCoin("uatom", 0), "katom")
+	require.NoError(t, err)
+	require.False(t, underflow)
+
+	_, _, err = ConvertDecCoinChecked(types.NewInt64DecCoin("atom", 1), "btc")
+	require.Error(t, err)
+}
+
+func TestParseCoinsNormalized(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	coins, err := ParseCoinsNormalized("1.5atom")
+	require.NoError(t, err)
+	require.Equal(t, types.Coins{types.NewInt64Coin("uatom", 1500000)}, coins)
+
+	coins, err = ParseCoinsNormalized("")
+	require.NoError(t, err)
+	require.Nil(t, coins)
+
+	// duplicate denoms, before and after normalization
+	_, err = ParseCoinsNormalized("1atom,2atom")
+	require.Error(t, err)
+	_, err = ParseCoinsNormalized("1atom,500000uatom")
+	require.Error(t, err)
+
+	_, err = ParseCoinsNormalized(strings.Repeat("1stake,", maxParsedCoins) + "1atom")
+	require.Error(t, err)
+
+	// normalizing to uatom overflows the amount
+	_, err = ParseCoinsNormalized(strings.Repeat("9", 77) + "atom")
+	require.Error(t, err)
+	_, err = ParseCoinsNormalized(strings.Repeat("9", 72) + "atom")
+	require.Error(t, err)
+	coins, err = ParseCoinsNormalized(strings.Repeat("9", 70) + "atom")
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("9", 70)+"000000", coins[0].Amount.String())
+
+	// normalized coins are re-sorted and truncated zero amounts dropped
+	coins, err = ParseCoinsNormalized("1atom,5tstake")
+	require.NoError(t, err)
+	require.Equal(t, types.Coins{types.NewInt64Coin("tstake", 5), types.NewInt64Coin("uatom", 1000000)}, coins)
+	require.True(t, coins.IsValid())
+	coins, err = ParseCoinsNormalized("0.1uatom,5tstake")
+	require.NoError(t, err)
+	require.Equal(t, types.Coins{types.NewInt64Coin("tstake", 5)}, coins)
+}
+
+func TestValidateCoinStrings(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	require.Empty(t, ValidateCoinStrings(nil))
+
+	errs := ValidateCoinStrings([]string{"1.5atom", "atom", "1atom,2stake", "1atom,500000uatom", "", "-1atom"})
+	require.Len(t, errs, 6)
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	require.NoError(t, errs[2])
+	require.Error(t, errs[3])
+	require.NoError(t, errs[4])
+	require.Error(t, errs[5])
+}
+
+func FuzzParseCoinsNormalized(f *testing.F) {
+	resetDenomRegistry()
+	if err := RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)); err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add("1.5atom,10stake")
+	f.Add("1atom,500000uatom")
+	f.Add(strings.Repeat("9", 77) + "atom")
+
+	f.Fuzz(func(t *testing.T, coinStr string) {
+		coins, err := ParseCoinsNormalized(coinStr)
+		if err != nil {
+			return
+		}
+		require.LessOrEqual(t, len(coins), maxParsedCoins)
+		require.True(t, coins.IsValid() || coins.Empty(), coins.String())
+	})
+}
+
+func TestConvertCoinToExponent(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+
+	coin, err := ConvertCoinToExponent(types.NewInt64Coin("uatom", 1500000), 6)
+	require.NoError(t, err)
+	require.Equal(t, types.NewDecCoinFromDec("atom", types.NewDecWithPrec(15, 1)), coin)
+
+	coin, err = ConvertCoinToExponent(types.NewInt64Coin("atom", 2), 3)
+	require.NoError(t, err)
+	require.Equal(t, types.NewDecCoinFromDec("matom", types.NewDec(2000)), coin)
+
+	coin, err = ConvertCoinToExponent(types.NewInt64Coin("uatom", 7), 0)
+	require.NoError(t, err)
+	require.Equal(t, types.NewDecCoinFromDec("uatom", types.NewDec(7)), coin)
+
+	// no denom is registered at exponent 2, nor at exponent 6 over a unit
+	// that is not exactly a power of ten
+	_, err = ConvertCoinToExponent(types.NewInt64Coin("uatom", 1), 2)
+	require.EqualError(t, err, "no denom registered at exponent 2 over uatom")
+	require.NoError(t, RegisterDenom("bgold", types.NewDecWithPrec(25, 1), "ugold", types.NewDecWithPrec(1, 6)))
+	_, err = ConvertCoinToExponent(types.NewInt64Coin("ugold", 1), 6)
+	require.Error(t, err)
+
+	_, err = ConvertCoinToExponent(types.NewInt64Coin("stake", 1), 6)
+	require.Error(t, err)
+	_, err = ConvertCoinToExponent(types.NewInt64Coin("uatom", 1), -1)
+	require.Error(t, err)
+}
+
+func TestGetDenomInfo(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	info, err := GetDenomInfo("atom")
+	require.NoError(t, err)
+	require.Equal(t, DenomInfo{
+		Denom:     "atom",
+		BaseDenom: "uatom",
+		Unit:      types.OneDec(),
+		BaseUnit:  types.NewDecWithPrec(1, 6),
+		Exponent:  6,
+	}, info)
+
+	info, err = GetDenomInfo("uatom")
+	require.NoError(t, err)
+	require.Equal(t, "uatom", info.BaseDenom)
+	require.Zero(t, info.Exponent)
+
+	_, err = GetDenomInfo("btc")
+	require.Error(t, err)
+}
+
+func TestDenomStep(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+
+	for denom, expected := range map[string]types.Dec{
+		"atom":  types.NewDecWithPrec(1, 6),
+		"matom": types.NewDecWithPrec(1, 3),
+		"uatom": types.OneDec(),
+	} {
+		step, err := DenomStep(denom)
+		require.NoError(t, err)
+		require.Equal(t, expected, step, denom)
+	}
+
+	_, err := DenomStep("btc")
+	require.Error(t, err)
+}
+
+func TestGetBaseUnits(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+
+	units, err := GetBaseUnits("uatom")
+	require.NoError(t, err)
+	require.Equal(t, []DenomUnit{
+		{Denom: "uatom", Exponent: 0},
+		{Denom: "matom", Exponent: 3},
+		{Denom: "atom", Exponent: 6},
+	}, units)
+
+	_, err = GetBaseUnits("atom")
+	require.Error(t, err)
+	_, err = GetBaseUnits("stake")
+	require.Error(t, err)
+}
+
+func TestDisplayDenomsByMagnitude(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	denoms, err := DisplayDenomsByMagnitude("uatom")
+	require.NoError(t, err)
+	require.Equal(t, []string{"atom", "matom", "uatom"}, denoms)
+
+	_, err = DisplayDenomsByMagnitude("atom")
+	require.Error(t, err)
+	_, err = DisplayDenomsByMagnitude("eth")
+	require.Error(t, err)
+}
+
+func TestDenomGraph(t *testing.T) {
+	resetDenomRegistry()
+	require.Empty(t, DenomGraph())
+
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	require.Equal(t, []DenomEdge{
+		{From: "btc", To: "satoshi", Weight: types.NewDec(100000000)},
+		{From: "matom", To: "uatom", Weight: types.NewDec(1000)},
+		{From: "atom", To: "uatom", Weight: types.NewDec(1000000)},
+	}, DenomGraph())
+}
+
+func TestCommonBaseDenom(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	base, err := CommonBaseDenom(types.NewCoins(
+		types.NewInt64Coin("atom", 1), types.NewInt64Coin("matom", 2), types.NewInt64Coin("uatom", 3),
+	))
+	require.NoError(t, err)
+	require.Equal(t, "uatom", base)
+
+	_, err = CommonBaseDenom(types.NewCoins(types.NewInt64Coin("atom", 1), types.NewInt64Coin("btc", 1)))
+	require.Error(t, err)
+	_, err = CommonBaseDenom(types.NewCoins(types.NewInt64Coin("atom", 1), types.NewInt64Coin("eth", 1)))
+	require.Error(t, err)
+	_, err = CommonBaseDenom(types.NewCoins())
+	require.Error(t, err)
+}
+
+func TestGetEquivalentDenoms(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("atom2", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+
+	require.Equal(t, []string{"atom2"}, GetEquivalentDenoms("atom"))
+	require.Equal(t, []string{"atom"}, GetEquivalentDenoms("atom2"))
+	require.Empty(t, GetEquivalentDenoms("matom"))
+	require.Empty(t, GetEquivalentDenoms("btc"))
+}
+
+func TestConvertCoinDustAware(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+
+	require.Error(t, SetDenomDustThreshold("btc", types.NewInt(1)))
+	require.Error(t, SetDenomDustThreshold("uatom", types.NewInt(-1)))
+	require.NoError(t, SetDenomDustThreshold("uatom", types.NewInt(2000)))
+
+	coin, err := ConvertCoinDustAware(types.NewInt64Coin("matom", 2), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 2000), coin)
+
+	// 1matom is 1000uatom, below the dust threshold
+	_, err = ConvertCoinDustAware(types.NewInt64Coin("matom", 1), "uatom")
+	require.Error(t, err)
+
+	coin, err = ConvertCoinDustAware(types.NewInt64Coin("matom", 0), "uatom")
+	require.NoError(t, err)
+	require.True(t, coin.IsZero())
+
+	require.NoError(t, SetDenomDustThreshold("uatom", types.ZeroInt()))
+	_, err = ConvertCoinDustAware(types.NewInt64Coin("matom", 1), "uatom")
+	require.NoError(t, err)
+}
+
+func TestConvertCoinMaxLoss(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	// 1500123uatom to atom drops 500123uatom
+	_, err := ConvertCoinMaxLoss(types.NewInt64Coin("uatom", 1500123), "atom", types.NewInt(500000))
+	require.Error(t, err)
+
+	coin, err := ConvertCoinMaxLoss(types.NewInt64Coin("uatom", 1500123), "atom", types.NewInt(500123))
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("atom", 1), coin)
+
+	coin, err = ConvertCoinMaxLoss(types.NewInt64Coin("atom", 2), "uatom", types.ZeroInt())
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 2000000), coin)
+}
+
+func TestConvertDecCoinNegative(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	refund := types.DecCoin{Denom: "atom", Amount: types.NewDecWithPrec(-15, 1)}
+
+	coin, err := ConvertDecCoin(refund, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, "uatom", coin.Denom)
+	require.Equal(t, types.NewDec(-1500000), coin.Amount)
+
+	coin, err = ConvertDecCoin(coin, "atom")
+	require.NoError(t, err)
+	require.Equal(t, refund, coin)
+
+	coin = NormalizeDecCoin(refund)
+	require.Equal(t, types.DecCoin{Denom: "uatom", Amount: types.NewDec(-1500000)}, coin)
+}
+
+func TestNativeDenom(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+
+	_, err := GetNativeDenom()
+	require.Error(t, err)
+
+	require.Error(t, SetNativeDenom("btc"))
+	require.NoError(t, SetNativeDenom("uatom"))
+
+	denom, err := GetNativeDenom()
+	require.NoError(t, err)
+	require.Equal(t, "uatom", denom)
+}
+
+func TestConvertCoinExponentFastPath(t *testing.T) {
+	resetDenomRegistry()
+	for exp := 0; exp < types.Precision; exp++ {
+		denom := fmt.Sprintf("e%datom", exp)
+		require.NoError(t, RegisterDenom(denom, powerOfTen(-exp), "e18atom", powerOfTen(-types.Precision)))
+	}
+	// a unit ratio that is not a power of ten takes the Dec path, even when its
+	// rounded quotient is one
+	require.NoError(t, RegisterDenom("thirdatom", types.NewDecWithPrec(3, 1), "e18atom", powerOfTen(-types.Precision)))
+	nearUnit, ok := types.NewIntFromString("10000000000000000001")
+	require.True(t, ok)
+	require.NoError(t, RegisterDenom("nearatom", types.NewDecFromInt(nearUnit), "e18atom", powerOfTen(-types.Precision)))
+	require.NoError(t, RegisterDenom("tenatom", powerOfTen(types.Precision).MulInt64(10), "e18atom", powerOfTen(-types.Precision)))
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		src := fmt.Sprintf("e%datom", r.Intn(types.Precision+1))
+		dst := fmt.Sprintf("e%datom", r.Intn(types.Precision+1))
+		amount := types.NewInt(r.Int63())
+		switch i % 10 {
+		case 0:
+			dst = "thirdatom"
+		case 1:
+			src, dst = "nearatom", "tenatom"
+			amount = amount.Add(powerOfTen(types.Precision).MulInt64(10).TruncateInt())
+		case 2:
+			src, dst = "tenatom", "nearatom"
+		}
+
+		coin, err := ConvertCoin(types.NewCoin(src, amount), dst)
+		require.NoError(t, err)
+
+		srcUnit, _ := GetDenomUnit(src)
+		dstUnit, _ := GetDenomUnit(dst)
+		require.Equal(t, convertAmountDec(amount, srcUnit, dstUnit), coin.Amount, "%s%s to %s", amount, src, dst)
+	}
+	require.False(t, defaultRegistry.exponentDeltaCache[denomPair{"e0atom", "thirdatom"}].clean)
+	require.False(t, defaultRegistry.exponentDeltaCache[denomPair{"nearatom", "tenatom"}].clean)
+
+	coin, err := ConvertCoin(types.NewCoin("nearatom", powerOfTen(types.Precision).MulInt64(10).TruncateInt()), "tenatom")
+	require.NoError(t, err)
+	require.Equal(t, "10000000000000000001", coin.Amount.String())
+}
+
+func BenchmarkConvertCoin(b *testing.B) {
+	resetDenomRegistry()
+	if err := RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)); err != nil {
+		b.Fatal(err)
+	}
+	coin := types.NewInt64Coin("atom", 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ConvertCoin(coin, "uatom"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkConvertAmountDec(b *testing.B) {
+	amount, srcUnit, dstUnit := types.NewInt(1), types.OneDec(), types.NewDecWithPrec(1, 6)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		convertAmountDec(amount, srcUnit, dstUnit)
+	}
+}
+
+func TestRegisteredDenomCount(t *testing.T) {
+	resetDenomRegistry()
+	require.Zero(t, RegisteredDenomCount())
+
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("matom", types.NewDecWithPrec(1, 3), "uatom", types.NewDecWithPrec(1, 6)))
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	// atom, matom and btc; the uatom and satoshi bases are not counted
+	require.Equal(t, 3, RegisteredDenomCount())
+}
+
+func TestRoundDecCoinToDisplay(t *testing.T) {
+	resetDenomRegistry()
+	require.NoError(t, RegisterDenomWithAlias("atom", "ATOM", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+
+	amount, err := types.NewDecFromStr("1.23456789")
+	require.NoError(t, err)
+
+	coin, err := RoundDecCoinToDisplay(types.NewDecCoinFromDec("atom", amount))
+	require.NoError(t, err)
+	require.Equal(t, types.NewDecCoinFromDec("atom", types.NewDecWithPrec(1234568, 6)), coin)
+
+	coin, err = RoundDecCoinToDisplay(types.NewDecCoinFromDec("ATOM", amount))
+	require.NoError(t, err)
+	require.Equal(t, "atom", coin.Denom)
+
+	// the base denom has no decimals
+	coin, err = RoundDecCoinToDisplay(types.NewDecCoinFromDec("uatom", amount))
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64DecCoin("uatom", 1), coin)
+
+	_, err = RoundDecCoinToDisplay(types.NewInt64DecCoin("btc", 1))
+	require.Error(t, err)
+}
+
+func TestDeprecateDenom(t *testing.T) {
+	resetDenomRegistry()
+	registerAtom(t)
+	require.NoError(t, RegisterDenom("btc", types.OneDec(), "satoshi", types.NewDecWithPrec(1, 8)))
+
+	require.Error(t, DeprecateDenom("eth"))
+	require.NoError(t, DeprecateDenom("atom"))
+	require.True(t, IsDenomDeprecated("atom"))
+	require.False(t, IsDenomDeprecated("uatom"))
+
+	coin, err := ConvertCoin(types.NewInt64Coin("atom", 1), "uatom")
+	require.NoError(t, err)
+	require.Equal(t, types.NewInt64Coin("uatom", 1000000), coin)
+
+	require.Equal(t, []DenomRegistryEntry{
+		{Denom: "atom", Base: "uatom", Unit: "1.000000000000000000", Deprecated: true},
+		{Denom: "btc", Base: "satoshi", Unit: "1.000000000000000000"},
+		{Denom: "satoshi", Base: "satoshi", Unit: "0.000000010000000000"},
+		{Denom: "uatom", Base: "uatom", Unit: "0.000001000000000000"},
+	}, ListRegisteredDenoms())
+}
+
+func TestRegisterDenomUnitOrder(t *testing.T) {
+	resetDenomRegistry()
+
+	err := RegisterDenom("atom", types.NewDecWithPrec(1, 6), "uatom", types.OneDec())
+	require.Error(t, err)
+	_, ok := GetDenomUnit("atom")
+	require.False(t, ok)
+
+	require.NoError(t, RegisterDenom("atom", types.OneDec(), "uatom", types.NewDecWithPrec(1, 6)))
+}
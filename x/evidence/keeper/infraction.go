@@ -17,6 +17,7 @@ import (
 //
 // The evidence is considered invalid if:
 // - the evidence is too old
+// - the infraction is older than the unbonding period
 // - the validator is unbonded or does not exist
 // - the signing info does not exist (will panic)
 // - is already tombstoned
@@ -64,6 +65,19 @@ func (k Keeper) HandleEquivocationEvidence(ctx sdk.Context, evidence *types.Equi
 		}
 	}
 
+	// Reject evidence of infractions older than the unbonding period, the stake
+	// distribution at that height can no longer be held accountable. Without an
+	// unbonding period the slashing keeper falls back to the max evidence age.
+	if k.slashingKeeper.IsInfractionTooOld(ctx, infractionHeight) {
+		logger.Info(
+			"ignored equivocation; infraction older than unbonding period",
+			"validator", consAddr,
+			"infraction_height", infractionHeight,
+			"infraction_time", infractionTime,
+		)
+		return
+	}
+
 	validator := k.stakingKeeper.ValidatorByConsAddr(ctx, consAddr)
 	if validator == nil {
 		// Defensive: Simulation doesn't take unbonding periods into account, and
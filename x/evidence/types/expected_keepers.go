@@ -24,5 +24,6 @@ type (
 		Tombstone(sdk.Context, sdk.ConsAddress)
 		Jail(sdk.Context, sdk.ConsAddress)
 		JailUntil(sdk.Context, sdk.ConsAddress, time.Time)
+		IsInfractionTooOld(sdk.Context, int64) bool
 	}
 )
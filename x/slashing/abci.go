@@ -20,6 +20,9 @@ import (
 func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
 	defer telemetry.ModuleMeasureSince(types.ModuleName, time.Now(), telemetry.MetricKeyBeginBlocker)
 
+	// params changed by governance are only written to the subspace
+	k.ApplyParamChanges(ctx)
+
 	logger := k.Logger(ctx)
 	k.Sk.IterateValidators(ctx, func(index int64, validator stakingtypes.ValidatorI) bool {
 		if validator.IsJailed() {
@@ -41,13 +44,24 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 					logger.Error("convert string address to consadress error", "err: ", err)
 					panic(fmt.Sprintf("Can not convert string address to consadress %s", signInfo.Address))
 				}
-				k.Sk.Unjail(ctx, signAddr)
+				if err := k.UnjailAfterJailPeriod(ctx, signAddr); err != nil {
+					// retried on the next blocks until the cooldown is over
+					logger.Debug("validator not unjailed", "validator", signAddr.String(), "err", err)
+					return false
+				}
+				logger.Info(
+					"unjailed validator after jail period",
+					"validator", signAddr.String(),
+					"jailed_until", signInfo.JailedUntil,
+				)
 			}
 		}
 
 		return false
 	})
 
+	k.PruneSlashRecords(ctx)
+
 	// Iterate over all the validators which *should* have signed this block
 	// store whether or not they have actually signed it and slash/unbond any
 	// which have missed too many blocks in a row (downtime slashing)
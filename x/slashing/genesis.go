@@ -10,8 +10,13 @@ import (
 )
 
 // InitGenesis initialize default parameters
-// and the keeper's address to pubkey map
+// and the keeper's address to pubkey map. It panics on a genesis state
+// failing ValidateGenesis.
 func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, stakingKeeper types.StakingKeeper, data *types.GenesisState) {
+	if err := types.ValidateGenesis(*data); err != nil {
+		panic(err)
+	}
+
 	stakingKeeper.IterateValidators(ctx,
 		func(index int64, validator stakingtypes.ValidatorI) bool {
 			consPk, err := validator.ConsPubKey()
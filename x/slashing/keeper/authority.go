@@ -0,0 +1,104 @@
+package keeper
+
+import (
+	gogotypes "github.com/gogo/protobuf/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/srstaking/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+// SetAuthority adds a validator to the PoA authority set, see
+// JailNonAuthorities.
+func (k Keeper) SetAuthority(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&gogotypes.BoolValue{Value: true})
+	store.Set(types.AuthorityKey(consAddr), bz)
+}
+
+// RemoveAuthority removes a validator from the PoA authority set.
+func (k Keeper) RemoveAuthority(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.AuthorityKey(consAddr))
+}
+
+// IsAuthority returns if a given validator is in the PoA authority set.
+func (k Keeper) IsAuthority(ctx sdk.Context, consAddr sdk.ConsAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.AuthorityKey(consAddr))
+	if bz == nil {
+		return false
+	}
+
+	var authority gogotypes.BoolValue
+	k.cdc.MustUnmarshal(bz, &authority)
+	return authority.Value
+}
+
+// hasAuthorities returns if the PoA authority set has any member.
+func (k Keeper) hasAuthorities(ctx sdk.Context) bool {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.AuthorityKeyPrefix)
+	defer iter.Close()
+	return iter.Valid()
+}
+
+// JailNonAuthorities jails every unjailed validator of the srstaking set that
+// isn't in the PoA authority set until DoubleSignJailEndTime, so the
+// BeginBlocker never unjails them, emitting a slash event with reason
+// "deauthorized" for each, and returns the jailed validators. It is a one-time
+// governance action, run after the authority set was updated. An empty
+// authority set is rejected since it would jail the whole validator set.
+func (k Keeper) JailNonAuthorities(ctx sdk.Context) (jailed []sdk.ConsAddress, err error) {
+	if !k.hasAuthorities(ctx) {
+		return nil, types.ErrEmptyAuthoritySet
+	}
+
+	var deauthorized []sdk.ConsAddress
+	k.Sk.IterateValidators(ctx, func(_ int64, validator stakingtypes.ValidatorI) (stop bool) {
+		if validator.IsJailed() {
+			return false
+		}
+
+		consAddr, addrErr := validator.GetConsAddr()
+		if addrErr != nil {
+			err = addrErr
+			return true
+		}
+		if k.IsAuthority(ctx, consAddr) {
+			return false
+		}
+		if _, found := k.GetValidatorSigningInfo(ctx, consAddr); !found {
+			err = sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+			return true
+		}
+		deauthorized = append(deauthorized, consAddr)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// jail outside of the iteration, which jailing may alter
+	jailed = []sdk.ConsAddress{}
+	for _, consAddr := range deauthorized {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeSlash,
+				sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+				sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueDeauthorized),
+				sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
+			),
+		)
+		k.Sk.Jail(ctx, consAddr)
+		k.JailUntil(ctx, consAddr, types.DoubleSignJailEndTime)
+		k.recordJailEvent(ctx, consAddr)
+		k.resetUptimeStreak(ctx, consAddr)
+		jailed = append(jailed, consAddr)
+	}
+
+	k.Logger(ctx).Info("jailed non-authority validators", "count", len(jailed))
+	return jailed, nil
+}
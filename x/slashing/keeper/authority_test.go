@@ -0,0 +1,92 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing"
+	"gea-poa/x/slashing/types"
+)
+
+func TestJailNonAuthorities(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	var addrs []sdk.ConsAddress
+	for i := 0; i < 4; i++ {
+		addr := sdk.ConsAddress(sk.addValidator().Address())
+		k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 0, time.Unix(0, 0), false, 0))
+		addrs = append(addrs, addr)
+	}
+
+	_, err := k.JailNonAuthorities(ctx)
+	require.ErrorIs(t, err, types.ErrEmptyAuthoritySet)
+
+	k.SetAuthority(ctx, addrs[0])
+	k.SetAuthority(ctx, addrs[2])
+	k.SetAuthority(ctx, addrs[3])
+	k.RemoveAuthority(ctx, addrs[3])
+	require.True(t, k.IsAuthority(ctx, addrs[0]))
+	require.False(t, k.IsAuthority(ctx, addrs[3]))
+
+	jailed, err := k.JailNonAuthorities(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ConsAddress{addrs[1], addrs[3]}, jailed)
+
+	for i, addr := range addrs {
+		require.Equal(t, i == 1 || i == 3, sk.byConsAddr(addr).IsJailed())
+	}
+	info, _ := k.GetValidatorSigningInfo(ctx, addrs[1])
+	require.True(t, types.DoubleSignJailEndTime.Equal(info.JailedUntil))
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 2)
+	for _, event := range events {
+		require.Equal(t, types.EventTypeSlash, event.Type)
+		require.Contains(t, event.Attributes, sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueDeauthorized).ToKVPair())
+	}
+
+	// already jailed validators are left alone
+	jailed, err = k.JailNonAuthorities(ctx)
+	require.NoError(t, err)
+	require.Empty(t, jailed)
+}
+
+func TestJailNonAuthoritiesSurvivesBeginBlocker(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	authority := sdk.ConsAddress(sk.addValidator().Address())
+	other := sdk.ConsAddress(sk.addValidator().Address())
+	for _, addr := range []sdk.ConsAddress{authority, other} {
+		k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 0, time.Unix(0, 0), false, 0))
+	}
+	k.SetAuthority(ctx, authority)
+
+	jailed, err := k.JailNonAuthorities(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.ConsAddress{other}, jailed)
+
+	for i := int64(1); i <= 3; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1).WithBlockTime(ctx.BlockTime().Add(24 * time.Hour))
+		slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+		require.True(t, sk.byConsAddr(other).IsJailed())
+		require.False(t, sk.byConsAddr(authority).IsJailed())
+	}
+}
+
+func TestJailNonAuthoritiesRequiresSigningInfo(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	authority := sdk.ConsAddress(sk.addValidator().Address())
+	other := sdk.ConsAddress(sk.addValidator().Address())
+	k.SetAuthority(ctx, authority)
+
+	_, err := k.JailNonAuthorities(ctx)
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+	require.False(t, sk.byConsAddr(other).IsJailed())
+}
@@ -1,8 +1,171 @@
 package keeper_test
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmdb "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/srstaking/types"
+
+	"gea-poa/x/slashing/keeper"
+	"gea-poa/x/slashing/types"
+)
 
 var (
 	// The default power validators are initialized to have within tests
 	InitTokens = sdk.TokensFromConsensusPower(200, sdk.DefaultPowerReduction)
 )
+
+// mockValidator is the minimal srstaking validator the slashing keeper needs.
+// Methods not overridden here panic through the nil embedded interface.
+type mockValidator struct {
+	stakingtypes.ValidatorI
+
+	operator sdk.ValAddress
+	pubkey   cryptotypes.PubKey
+	jailed   bool
+	tokens   sdk.Int
+}
+
+// slashCall records the arguments of a mockStakingKeeper.Slash call.
+type slashCall struct {
+	consAddr         sdk.ConsAddress
+	infractionHeight int64
+	power            int64
+	fraction         sdk.Dec
+}
+
+func (v *mockValidator) IsJailed() bool                          { return v.jailed }
+func (v *mockValidator) GetMoniker() string                      { return v.operator.String() }
+func (v *mockValidator) GetOperator() sdk.ValAddress             { return v.operator }
+func (v *mockValidator) ConsPubKey() (cryptotypes.PubKey, error) { return v.pubkey, nil }
+func (v *mockValidator) GetConsAddr() (sdk.ConsAddress, error) {
+	return sdk.ConsAddress(v.pubkey.Address()), nil
+}
+
+// mockStakingKeeper implements types.StakingKeeper over an in-memory validator set.
+type mockStakingKeeper struct {
+	validators    []*mockValidator
+	unbondingTime time.Duration
+	slashes       []slashCall
+}
+
+var _ types.BondedStakingKeeper = &mockStakingKeeper{}
+
+func (sk *mockStakingKeeper) IterateValidators(_ sdk.Context, fn func(index int64, validator stakingtypes.ValidatorI) (stop bool)) {
+	for i, v := range sk.validators {
+		if fn(int64(i), v) {
+			return
+		}
+	}
+}
+
+func (sk *mockStakingKeeper) GETValidator(_ sdk.Context, valAddr sdk.ValAddress) stakingtypes.ValidatorI {
+	for _, v := range sk.validators {
+		if v.operator.Equals(valAddr) {
+			return v
+		}
+	}
+	return nil
+}
+
+func (sk *mockStakingKeeper) ValidatorByConsAddr(_ sdk.Context, consAddr sdk.ConsAddress) stakingtypes.ValidatorI {
+	if v := sk.byConsAddr(consAddr); v != nil {
+		return v
+	}
+	return nil
+}
+
+// Slash burns the slashed fraction of the tokens of the given power, capped by
+// the tokens the validator still holds.
+func (sk *mockStakingKeeper) Slash(_ sdk.Context, consAddr sdk.ConsAddress, infractionHeight, power int64, fraction sdk.Dec) sdk.Int {
+	sk.slashes = append(sk.slashes, slashCall{consAddr, infractionHeight, power, fraction})
+
+	v := sk.byConsAddr(consAddr)
+	burned := sdk.MinInt(fraction.MulInt(sdk.TokensFromConsensusPower(power, sdk.DefaultPowerReduction)).TruncateInt(), v.tokens)
+	v.tokens = v.tokens.Sub(burned)
+	return burned
+}
+
+func (sk *mockStakingKeeper) Jail(_ sdk.Context, consAddr sdk.ConsAddress) {
+	sk.byConsAddr(consAddr).jailed = true
+}
+
+func (sk *mockStakingKeeper) Unjail(_ sdk.Context, consAddr sdk.ConsAddress) {
+	sk.byConsAddr(consAddr).jailed = false
+}
+
+func (sk *mockStakingKeeper) MaxValidators(sdk.Context) uint32 {
+	return 100
+}
+
+func (sk *mockStakingKeeper) UnbondingTime(sdk.Context) time.Duration {
+	return sk.unbondingTime
+}
+
+func (sk *mockStakingKeeper) BondDenom(sdk.Context) string {
+	return sdk.DefaultBondDenom
+}
+
+func (sk *mockStakingKeeper) byConsAddr(consAddr sdk.ConsAddress) *mockValidator {
+	for _, v := range sk.validators {
+		if sdk.ConsAddress(v.pubkey.Address()).Equals(consAddr) {
+			return v
+		}
+	}
+	return nil
+}
+
+// addValidator registers a fresh validator with the mock staking keeper and
+// returns its consensus pubkey.
+func (sk *mockStakingKeeper) addValidator() cryptotypes.PubKey {
+	pk := ed25519.GenPrivKey().PubKey()
+	sk.validators = append(sk.validators, &mockValidator{
+		operator: sdk.ValAddress(pk.Address()),
+		pubkey:   pk,
+		tokens:   InitTokens,
+	})
+	return pk
+}
+
+// createTestInput returns a slashing keeper backed by an in-memory store, the
+// mock staking keeper it was wired with and a context with default params set.
+func createTestInput(t *testing.T) (sdk.Context, keeper.Keeper, *mockStakingKeeper) {
+	keySlashing := sdk.NewKVStoreKey(types.StoreKey)
+	tkeySlashing := sdk.NewTransientStoreKey(types.TStoreKey)
+	keyParams := sdk.NewKVStoreKey(paramstypes.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(paramstypes.TStoreKey)
+
+	db := tmdb.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keySlashing, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeySlashing, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	subspace := paramstypes.NewSubspace(cdc, codec.NewLegacyAmino(), keyParams, tkeyParams, types.ModuleName)
+	sk := &mockStakingKeeper{}
+	k := keeper.NewKeeper(cdc, keySlashing, tkeySlashing, sk, subspace)
+
+	ctx := sdk.NewContext(ms, tmproto.Header{Height: 1, Time: time.Unix(1600000000, 0).UTC()}, false, log.NewNopLogger())
+	k.SetParams(ctx, types.DefaultParams())
+
+	return ctx, k, sk
+}
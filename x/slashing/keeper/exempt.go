@@ -0,0 +1,37 @@
+package keeper
+
+import (
+	gogotypes "github.com/gogo/protobuf/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+// SetSlashExempt exempts a validator from downtime slashing. Exempt validators
+// are still jailed for downtime, e.g. foundation maintained infrastructure
+// validators on a PoA chain.
+func (k Keeper) SetSlashExempt(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&gogotypes.BoolValue{Value: true})
+	store.Set(types.ValidatorSlashExemptKey(consAddr), bz)
+}
+
+// RemoveSlashExempt removes the downtime slashing exemption of a validator.
+func (k Keeper) RemoveSlashExempt(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.ValidatorSlashExemptKey(consAddr))
+}
+
+// IsSlashExempt returns if a given validator is exempt from downtime slashing.
+func (k Keeper) IsSlashExempt(ctx sdk.Context, consAddr sdk.ConsAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.ValidatorSlashExemptKey(consAddr))
+	if bz == nil {
+		return false
+	}
+
+	var exempt gogotypes.BoolValue
+	k.cdc.MustUnmarshal(bz, &exempt)
+	return exempt.Value
+}
@@ -6,6 +6,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
@@ -73,3 +75,63 @@ func (k Keeper) SigningInfos(c context.Context, req *types.QuerySigningInfosRequ
 	}
 	return &types.QuerySigningInfosResponse{Info: signInfos, Pagination: pageRes}, nil
 }
+
+func (k Keeper) Pubkey(c context.Context, req *types.QueryPubkeyRequest) (*types.QueryPubkeyResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "empty request")
+	}
+
+	if req.ConsAddress == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request")
+	}
+
+	consAddr, err := sdk.ConsAddressFromBech32(req.ConsAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	pubkey, err := k.GetPubkey(ctx, consAddr.Bytes())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Pubkey not found for validator %s", req.ConsAddress)
+	}
+
+	pkAny, err := codectypes.NewAnyWithValue(pubkey)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryPubkeyResponse{Pubkey: pkAny}, nil
+}
+
+func (k Keeper) Pubkeys(c context.Context, req *types.QueryPubkeysRequest) (*types.QueryPubkeysResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	store := ctx.KVStore(k.storeKey)
+	var relations []types.AddrPubkeyRelation
+
+	pubkeyStore := prefix.NewStore(store, types.AddrPubkeyRelationKeyPrefix)
+	pageRes, err := query.Paginate(pubkeyStore, req.Pagination, func(key []byte, value []byte) error {
+		var pubkey cryptotypes.PubKey
+		err := k.cdc.UnmarshalInterface(value, &pubkey)
+		if err != nil {
+			return err
+		}
+		pkAny, err := codectypes.NewAnyWithValue(pubkey)
+		if err != nil {
+			return err
+		}
+		relations = append(relations, types.AddrPubkeyRelation{
+			ConsAddress: types.AddrPubkeyRelationAddress(key).String(),
+			Pubkey:      pkAny,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryPubkeysResponse{Pubkeys: relations, Pagination: pageRes}, nil
+}
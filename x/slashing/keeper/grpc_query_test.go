@@ -0,0 +1,42 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+
+	"gea-poa/x/slashing/types"
+)
+
+func TestGRPCQueryPubkey(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	c := sdk.WrapSDKContext(ctx)
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	require.NoError(t, k.AddPubkey(ctx, pk))
+
+	res, err := k.Pubkey(c, &types.QueryPubkeyRequest{ConsAddress: consAddr.String()})
+	require.NoError(t, err)
+	queried, ok := res.Pubkey.GetCachedValue().(cryptotypes.PubKey)
+	require.True(t, ok)
+	require.True(t, pk.Equals(queried))
+
+	_, err = k.Pubkey(c, &types.QueryPubkeyRequest{ConsAddress: sdk.ConsAddress("unknown").String()})
+	require.Error(t, err)
+
+	_, err = k.Pubkey(c, &types.QueryPubkeyRequest{})
+	require.Error(t, err)
+
+	list, err := k.Pubkeys(c, &types.QueryPubkeysRequest{Pagination: &query.PageRequest{Limit: 10}})
+	require.NoError(t, err)
+	require.Len(t, list.Pubkeys, 1)
+	require.Equal(t, consAddr.String(), list.Pubkeys[0].ConsAddress)
+	listed, ok := list.Pubkeys[0].Pubkey.GetCachedValue().(cryptotypes.PubKey)
+	require.True(t, ok)
+	require.True(t, pk.Equals(listed))
+}
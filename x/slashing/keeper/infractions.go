@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/telemetry"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	//"github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"gea-poa/x/slashing/types"
 )
@@ -14,10 +16,13 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr cryptotypes.Addre
 	logger := k.Logger(ctx)
 	height := ctx.BlockHeight()
 
-	// fetch the validator public key
+	// fetch the validator public key; validators without a relation, e.g.
+	// just added to the set, are skipped rather than given signing info
 	consAddr := sdk.ConsAddress(addr)
 	if _, err := k.GetPubkey(ctx, addr); err != nil {
-		panic(fmt.Sprintf("Validator consensus-address %s not found", consAddr))
+		logger.Debug("skipping signature of validator without pubkey relation", "height", height, "validator", consAddr.String())
+		telemetry.IncrCounter(1, types.ModuleName, "unknown_validator_signature")
+		return
 	}
 
 	// fetch signing info
@@ -49,9 +54,18 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr cryptotypes.Addre
 		// Array value at this index has not changed, no need to update counter
 	}
 
+	if signed {
+		k.setLastSignedHeight(ctx, consAddr, height)
+		k.incrementUptimeStreak(ctx, consAddr)
+	} else {
+		k.resetUptimeStreak(ctx, consAddr)
+	}
+
 	minSignedPerWindow := k.MinSignedPerWindow(ctx)
 
 	if missed {
+		k.updatePenaltySummary(ctx, consAddr, func(summary *types.PenaltySummary) { summary.MissedBlocks++ })
+
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
 				types.EventTypeLiveness,
@@ -73,8 +87,9 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr cryptotypes.Addre
 	minHeight := signInfo.StartHeight + k.SignedBlocksWindow(ctx)
 	maxMissed := k.SignedBlocksWindow(ctx) - minSignedPerWindow
 
-	// if we are past the minimum height and the validator has missed too many blocks, punish them
-	if height > minHeight && signInfo.MissedBlocksCounter > maxMissed {
+	// if we are past the minimum height and the validator has missed too many blocks, punish them,
+	// unless downtime jailing is paused
+	if height > minHeight && signInfo.MissedBlocksCounter > maxMissed && !k.IsDowntimeJailingPaused(ctx) {
 		validator := k.Sk.ValidatorByConsAddr(ctx, consAddr)
 		if validator != nil && !validator.IsJailed() {
 			// Downtime confirmed: slash and jail the validator
@@ -84,18 +99,41 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr cryptotypes.Addre
 			// i.e. at the end of the pre-genesis block (none) = at the beginning of the genesis block.
 			// That's fine since this is just used to filter unbonding delegations & redelegations.
 
-			ctx.EventManager().EmitEvent(
-				sdk.NewEvent(
-					types.EventTypeSlash,
-					sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
-					sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
-					sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueMissingSignature),
-					sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
-				),
-			)
+			if k.IsSlashExempt(ctx, consAddr) {
+				// Exempt validators are jailed, but never slashed for downtime.
+				ctx.EventManager().EmitEvents(sdk.Events{
+					sdk.NewEvent(
+						types.EventTypeSlash,
+						sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+						sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
+						sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueMissingSignature),
+						sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
+					),
+					sdk.NewEvent(
+						types.EventTypeSlashSkipped,
+						sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+						sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueSlashExempt),
+					),
+				})
+			} else {
+				ctx.EventManager().EmitEvent(
+					sdk.NewEvent(
+						types.EventTypeSlash,
+						sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+						sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
+						sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueMissingSignature),
+						sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
+					),
+				)
+			}
 			k.Sk.Jail(ctx, consAddr)
+			k.recordJailEvent(ctx, consAddr)
 
-			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeJailDuration(ctx))
+			// Repeat offenders are jailed for longer, see CurrentDowntimeJailDuration.
+			strikes := k.DowntimeStrikes(ctx, consAddr)
+			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.CurrentDowntimeJailDuration(ctx, consAddr))
+			k.SetDowntimeStrikes(ctx, consAddr, strikes+1)
+			k.updatePenaltySummary(ctx, consAddr, func(summary *types.PenaltySummary) { summary.DowntimeJails++ })
 
 			// We need to reset the counter & array so that the validator won't be immediately slashed for downtime upon rebonding.
 			signInfo.MissedBlocksCounter = 0
@@ -108,6 +146,7 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr cryptotypes.Addre
 				"validator", consAddr.String(),
 				"min_height", minHeight,
 				"threshold", minSignedPerWindow,
+				"strikes", strikes+1,
 				"jailed_until", signInfo.JailedUntil,
 			)
 		} else {
@@ -122,3 +161,92 @@ func (k Keeper) HandleValidatorSignature(ctx sdk.Context, addr cryptotypes.Addre
 	// Set the updated signing info
 	k.SetValidatorSigningInfo(ctx, consAddr, signInfo)
 }
+
+// ValidatorsNearJailThreshold returns the unjailed validators whose missed
+// blocks counter is within marginBlocks of the downtime jail threshold, i.e.
+// that would be jailed after missing at most marginBlocks+1 more blocks.
+// Validators already past the threshold are not included.
+func (k Keeper) ValidatorsNearJailThreshold(ctx sdk.Context, marginBlocks int64) []sdk.ConsAddress {
+	maxMissed := k.SignedBlocksWindow(ctx) - k.MinSignedPerWindow(ctx)
+
+	addrs := []sdk.ConsAddress{}
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info types.ValidatorSigningInfo) (stop bool) {
+		if info.MissedBlocksCounter > maxMissed || info.MissedBlocksCounter < maxMissed-marginBlocks {
+			return false
+		}
+
+		validator := k.Sk.ValidatorByConsAddr(ctx, address)
+		if validator != nil && !validator.IsJailed() {
+			addrs = append(addrs, address)
+		}
+		return false
+	})
+
+	return addrs
+}
+
+// WouldBeJailedAfter returns whether a validator would be jailed for downtime
+// if it missed the next additionalMisses blocks, replaying them against its
+// current signing info and missed block bit array without writing any state.
+// Validators that are unknown to srstaking or already jailed are never jailed,
+// nor is any validator while downtime jailing is paused.
+func (k Keeper) WouldBeJailedAfter(ctx sdk.Context, consAddr sdk.ConsAddress, additionalMisses int64) (bool, error) {
+	signInfo, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return false, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	if k.IsDowntimeJailingPaused(ctx) {
+		return false, nil
+	}
+
+	validator := k.Sk.ValidatorByConsAddr(ctx, consAddr)
+	if validator == nil || validator.IsJailed() {
+		return false, nil
+	}
+
+	window := k.SignedBlocksWindow(ctx)
+	minHeight := signInfo.StartHeight + window
+	maxMissed := window - k.MinSignedPerWindow(ctx)
+
+	missedCounter := signInfo.MissedBlocksCounter
+	for i := int64(0); i < additionalMisses; i++ {
+		index := (signInfo.IndexOffset + i) % window
+		// positions revisited within the replay were already counted as missed
+		if i < window && !k.GetValidatorMissedBlockBitArray(ctx, consAddr, index) {
+			missedCounter++
+		}
+
+		if height := ctx.BlockHeight() + i + 1; height > minHeight && missedCounter > maxMissed {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// IsInfractionTooOld returns whether an infraction committed at the given
+// height is older than the unbonding period, converted to blocks using
+// types.ExpectedBlockTime. Evidence of such infractions must be ignored.
+// Without a BondedStakingKeeper, as with srstaking, there is no unbonding
+// period and the max evidence age of the consensus params is used instead:
+// infractions are too old once older than both its MaxAgeNumBlocks and its
+// MaxAgeDuration in blocks. Without either, no infraction is too old.
+func (k Keeper) IsInfractionTooOld(ctx sdk.Context, infractionHeight int64) bool {
+	var maxAgeBlocks int64
+	if sk, ok := k.bondedStaking(); ok {
+		maxAgeBlocks = int64(sk.UnbondingTime(ctx) / types.ExpectedBlockTime)
+	} else {
+		cp := ctx.ConsensusParams()
+		if cp == nil || cp.Evidence == nil {
+			return false
+		}
+
+		maxAgeBlocks = int64(cp.Evidence.MaxAgeDuration / types.ExpectedBlockTime)
+		if cp.Evidence.MaxAgeNumBlocks > maxAgeBlocks {
+			maxAgeBlocks = cp.Evidence.MaxAgeNumBlocks
+		}
+	}
+
+	return infractionHeight < ctx.BlockHeight()-maxAgeBlocks
+}
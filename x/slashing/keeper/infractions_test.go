@@ -0,0 +1,348 @@
+package keeper_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/keeper"
+	"gea-poa/x/slashing/types"
+)
+
+// setupLiveness shrinks the signed blocks window to 10 blocks, of which 5 must
+// be signed, and registers a bonded validator starting at the context height.
+func setupLiveness(t *testing.T, ctx sdk.Context, k keeper.Keeper, sk *mockStakingKeeper) cryptotypes.PubKey {
+	k.SetParams(ctx, types.NewParams(10, sdk.NewDecWithPrec(5, 1), types.DefaultDowntimeJailDuration, sdk.ZeroDec(), sdk.ZeroDec(), 0, 0))
+
+	pk := sk.addValidator()
+	require.NoError(t, k.AddPubkey(ctx, pk))
+	k.AfterValidatorBonded(ctx, sdk.ConsAddress(pk.Address()), sdk.ValAddress(pk.Address()))
+	return pk
+}
+
+// missBlocks feeds n missed signatures for pk starting at the context height
+// and returns the context positioned at the last processed height.
+func missBlocks(ctx sdk.Context, k keeper.Keeper, pk cryptotypes.PubKey, n int64) sdk.Context {
+	for i := int64(0); i < n; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, false)
+	}
+	return ctx
+}
+
+func TestHandleValidatorSignatureSkipsUnknownValidator(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	consAddr := sdk.ConsAddress("unknown")
+
+	require.NotPanics(t, func() {
+		k.HandleValidatorSignature(ctx, consAddr.Bytes(), 1, false)
+	})
+	require.False(t, k.HasValidatorSigningInfo(ctx, consAddr))
+	require.False(t, k.GetValidatorMissedBlockBitArray(ctx, consAddr, 0))
+}
+
+func TestDowntimeJailingPaused(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.SetDowntimeJailingPaused(ctx, true)
+	k.SetDowntimeJailingPaused(ctx, true)
+	require.True(t, k.IsDowntimeJailingPaused(ctx))
+	require.Len(t, ctx.EventManager().Events(), 1)
+	require.Equal(t, types.EventTypeDowntimeJailing, ctx.EventManager().Events()[0].Type)
+
+	// get past the first window while signing, then miss well past the threshold
+	for i := 0; i < 10; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	}
+	ctx = missBlocks(ctx, k, pk, 8)
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, int64(8), info.MissedBlocksCounter)
+
+	// misses were recorded, so the validator is jailed once jailing resumes
+	k.SetDowntimeJailingPaused(ctx, false)
+	require.False(t, k.IsDowntimeJailingPaused(ctx))
+	missBlocks(ctx, k, pk, 1)
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+}
+
+func TestHandleValidatorSignatureEscalatesDowntimeJail(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	// get past the first window while signing
+	for i := 0; i < 10; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	}
+
+	for strike := int64(0); strike < 3; strike++ {
+		require.Equal(t, strike, k.DowntimeStrikes(ctx, consAddr))
+
+		ctx = missBlocks(ctx, k, pk, 6)
+		require.True(t, sk.byConsAddr(consAddr).IsJailed())
+		require.Equal(t, strike+1, k.DowntimeStrikes(ctx, consAddr))
+
+		info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+		require.True(t, found)
+		expected := ctx.BlockHeader().Time.Add(types.DefaultDowntimeJailDuration << uint64(strike))
+		require.Equal(t, expected, info.JailedUntil)
+
+		sk.Unjail(ctx, consAddr)
+	}
+
+	k.ClearDowntimeStrikes(ctx, consAddr)
+	require.Zero(t, k.DowntimeStrikes(ctx, consAddr))
+}
+
+func TestEscalatedDowntimeJailDurationCap(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+
+	base := k.DowntimeJailDuration(ctx)
+	require.Equal(t, base, k.EscalatedDowntimeJailDuration(ctx, 0))
+	require.Equal(t, 4*base, k.EscalatedDowntimeJailDuration(ctx, 2))
+	require.Equal(t, base<<uint64(types.MaxDowntimeStrikeEscalation), k.EscalatedDowntimeJailDuration(ctx, 100))
+
+	params := k.GetParams(ctx)
+	params.DowntimeJailDuration = time.Duration(math.MaxInt64 / 2)
+	k.SetParams(ctx, params)
+	require.Equal(t, time.Duration(math.MaxInt64), k.EscalatedDowntimeJailDuration(ctx, 100))
+}
+
+func TestCurrentDowntimeJailDuration(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	consAddr := sdk.ConsAddress(sk.addValidator().Address())
+
+	require.Equal(t, k.DowntimeJailDuration(ctx), k.CurrentDowntimeJailDuration(ctx, consAddr))
+
+	k.SetDowntimeJailDurationOverride(ctx, consAddr, time.Hour)
+	k.SetDowntimeStrikes(ctx, consAddr, 2)
+	require.Equal(t, 4*time.Hour, k.CurrentDowntimeJailDuration(ctx, consAddr))
+
+	k.RemoveDowntimeJailDurationOverride(ctx, consAddr)
+	require.Equal(t, 4*k.DowntimeJailDuration(ctx), k.CurrentDowntimeJailDuration(ctx, consAddr))
+}
+
+func TestIsInfractionTooOld(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	// 100 blocks worth of unbonding period
+	sk.unbondingTime = 100 * types.ExpectedBlockTime
+	ctx = ctx.WithBlockHeight(1000)
+
+	require.True(t, k.IsInfractionTooOld(ctx, 1))
+	require.True(t, k.IsInfractionTooOld(ctx, 899))
+	require.False(t, k.IsInfractionTooOld(ctx, 900))
+	require.False(t, k.IsInfractionTooOld(ctx, 999))
+
+	// nothing is too old before a full unbonding period has passed
+	require.False(t, k.IsInfractionTooOld(ctx.WithBlockHeight(50), 1))
+
+	// without an unbonding period, as with srstaking, nothing is too old
+	// unless the consensus params limit the evidence age
+	k.Sk = struct{ types.StakingKeeper }{sk}
+	require.False(t, k.IsInfractionTooOld(ctx, 1))
+
+	// the larger of both limits applies, here 200 blocks
+	ctx = ctx.WithConsensusParams(&abci.ConsensusParams{Evidence: &tmproto.EvidenceParams{
+		MaxAgeNumBlocks: 200,
+		MaxAgeDuration:  50 * types.ExpectedBlockTime,
+	}})
+	require.True(t, k.IsInfractionTooOld(ctx, 799))
+	require.False(t, k.IsInfractionTooOld(ctx, 800))
+	ctx = ctx.WithConsensusParams(&abci.ConsensusParams{Evidence: &tmproto.EvidenceParams{
+		MaxAgeNumBlocks: 50,
+		MaxAgeDuration:  200 * types.ExpectedBlockTime,
+	}})
+	require.True(t, k.IsInfractionTooOld(ctx, 799))
+	require.False(t, k.IsInfractionTooOld(ctx, 800))
+	require.False(t, k.IsInfractionTooOld(ctx.WithBlockHeight(150), 1))
+}
+
+func TestHandleValidatorSignatureSlashExempt(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	require.False(t, k.IsSlashExempt(ctx, consAddr))
+	k.SetSlashExempt(ctx, consAddr)
+	require.True(t, k.IsSlashExempt(ctx, consAddr))
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	ctx = missBlocks(ctx, k, pk, 16)
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+
+	// the slash event carries the same attributes as for other validators
+	var skipped bool
+	for _, event := range ctx.EventManager().Events() {
+		switch event.Type {
+		case types.EventTypeSlashSkipped:
+			skipped = true
+		case types.EventTypeSlash:
+			require.Contains(t, event.Attributes, abci.EventAttribute{Key: []byte(types.AttributeKeyPower), Value: []byte("1")})
+		}
+	}
+	require.True(t, skipped)
+
+	k.RemoveSlashExempt(ctx, consAddr)
+	require.False(t, k.IsSlashExempt(ctx, consAddr))
+}
+
+func TestValidatorsNearJailThreshold(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	// window of 10 blocks, jailed once more than 5 are missed
+	k.SetParams(ctx, types.NewParams(10, sdk.NewDecWithPrec(5, 1), types.DefaultDowntimeJailDuration, sdk.ZeroDec(), sdk.ZeroDec(), 0, 0))
+
+	var near []sdk.ConsAddress
+	for _, missed := range []int64{0, 2, 3, 5, 6, 5} {
+		pk := sk.addValidator()
+		consAddr := sdk.ConsAddress(pk.Address())
+		k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 10, ctx.BlockTime(), false, missed))
+		if missed == 3 || missed == 5 {
+			near = append(near, consAddr)
+		}
+	}
+
+	// the last validator is already jailed
+	last := sk.validators[len(sk.validators)-1]
+	sk.Jail(ctx, sdk.ConsAddress(last.pubkey.Address()))
+	near = near[:len(near)-1]
+
+	require.ElementsMatch(t, near, k.ValidatorsNearJailThreshold(ctx, 2))
+}
+
+func TestWouldBeJailedAfter(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	_, err := k.WouldBeJailedAfter(ctx, sdk.ConsAddress("unknown"), 1)
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	// get past the first window while signing, then miss right up to the threshold
+	for i := 0; i < 10; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	}
+	ctx = missBlocks(ctx, k, pk, 5)
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	before, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+
+	jailed, err := k.WouldBeJailedAfter(ctx, consAddr, 0)
+	require.NoError(t, err)
+	require.False(t, jailed)
+
+	jailed, err = k.WouldBeJailedAfter(ctx, consAddr, 1)
+	require.NoError(t, err)
+	require.True(t, jailed)
+
+	// no jailing is predicted, nor performed, while downtime jailing is paused
+	pausedCtx, _ := ctx.CacheContext()
+	k.SetDowntimeJailingPaused(pausedCtx, true)
+	jailed, err = k.WouldBeJailedAfter(pausedCtx, consAddr, 1)
+	require.NoError(t, err)
+	require.False(t, jailed)
+	missBlocks(pausedCtx, k, pk, 1)
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	after, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, before, after)
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	// the prediction holds
+	missBlocks(ctx, k, pk, 1)
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+}
+
+func TestLongestUptimeStreak(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	addr, streak := k.LongestUptimeStreak(ctx)
+	require.Nil(t, addr)
+	require.Zero(t, streak)
+
+	pks := []cryptotypes.PubKey{setupLiveness(t, ctx, k, sk), setupLiveness(t, ctx, k, sk), setupLiveness(t, ctx, k, sk)}
+
+	// validator 0 signs throughout but misses once, validator 1 only misses
+	// at the start and validator 2 never signs
+	for i := 0; i < 8; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pks[0].Address(), 1, i != 5)
+		k.HandleValidatorSignature(ctx, pks[1].Address(), 1, i >= 2)
+		k.HandleValidatorSignature(ctx, pks[2].Address(), 1, false)
+	}
+
+	addr, streak = k.LongestUptimeStreak(ctx)
+	require.Equal(t, sdk.ConsAddress(pks[1].Address()), addr)
+	require.Equal(t, int64(6), streak)
+
+	// a miss resets the leader's streak
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.HandleValidatorSignature(ctx, pks[0].Address(), 1, true)
+	k.HandleValidatorSignature(ctx, pks[1].Address(), 1, false)
+
+	addr, streak = k.LongestUptimeStreak(ctx)
+	require.Equal(t, sdk.ConsAddress(pks[0].Address()), addr)
+	require.Equal(t, int64(3), streak)
+}
+
+func TestLongestUptimeStreakEndsWhenValidatorStopsVoting(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pks := []cryptotypes.PubKey{
+		setupLiveness(t, ctx, k, sk), setupLiveness(t, ctx, k, sk), setupLiveness(t, ctx, k, sk), setupLiveness(t, ctx, k, sk),
+	}
+	addrs := make([]sdk.ConsAddress, len(pks))
+	for i, pk := range pks {
+		addrs[i] = sdk.ConsAddress(pk.Address())
+	}
+
+	// validator i signs the last 5-i blocks
+	for i := 0; i < 5; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		for j, pk := range pks {
+			k.HandleValidatorSignature(ctx, pk.Address(), 1, i >= j)
+		}
+	}
+	addr, streak := k.LongestUptimeStreak(ctx)
+	require.Equal(t, addrs[0], addr)
+	require.Equal(t, int64(5), streak)
+
+	// jailed validators stop voting, their streak ends even once unjailed
+	k.Jail(ctx, addrs[0])
+	addr, _ = k.LongestUptimeStreak(ctx)
+	require.Equal(t, addrs[1], addr)
+	sk.Unjail(ctx, addrs[0])
+	addr, _ = k.LongestUptimeStreak(ctx)
+	require.Equal(t, addrs[1], addr)
+
+	k.Tombstone(ctx, addrs[1])
+	addr, _ = k.LongestUptimeStreak(ctx)
+	require.Equal(t, addrs[2], addr)
+
+	require.NoError(t, k.RotatePubkey(ctx, pks[2], ed25519.GenPrivKey().PubKey()))
+	addr, _ = k.LongestUptimeStreak(ctx)
+	require.Equal(t, addrs[3], addr)
+
+	// validators removed from srstaking are skipped
+	sk.validators = sk.validators[:3]
+	addr, streak = k.LongestUptimeStreak(ctx)
+	require.Nil(t, addr)
+	require.Zero(t, streak)
+}
@@ -2,12 +2,15 @@ package keeper
 
 import (
 	"fmt"
+	"time"
 
+	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	//"github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"gea-poa/x/slashing/types"
 )
@@ -15,13 +18,14 @@ import (
 // Keeper of the slashing store
 type Keeper struct {
 	storeKey   sdk.StoreKey
+	tkey       sdk.StoreKey
 	cdc        codec.BinaryCodec
 	Sk         types.StakingKeeper
 	paramspace types.ParamSubspace
 }
 
 // NewKeeper creates a slashing keeper
-func NewKeeper(cdc codec.BinaryCodec, key sdk.StoreKey, sk types.StakingKeeper, paramspace types.ParamSubspace) Keeper {
+func NewKeeper(cdc codec.BinaryCodec, key, tkey sdk.StoreKey, sk types.StakingKeeper, paramspace types.ParamSubspace) Keeper {
 	// set KeyTable if it has not already been set
 	if !paramspace.HasKeyTable() {
 		paramspace = paramspace.WithKeyTable(types.ParamKeyTable())
@@ -29,17 +33,30 @@ func NewKeeper(cdc codec.BinaryCodec, key sdk.StoreKey, sk types.StakingKeeper,
 
 	return Keeper{
 		storeKey:   key,
+		tkey:       tkey,
 		cdc:        cdc,
 		Sk:         sk,
 		paramspace: paramspace,
 	}
 }
 
+// bondedStaking returns the staking keeper as a BondedStakingKeeper, and
+// whether it implements it at all.
+func (k Keeper) bondedStaking() (types.BondedStakingKeeper, bool) {
+	sk, ok := k.Sk.(types.BondedStakingKeeper)
+	return sk, ok
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", "x/"+types.ModuleName)
 }
 
+// ParamsSubspace returns the params subspace of the slashing module.
+func (k Keeper) ParamsSubspace() types.ParamSubspace {
+	return k.paramspace
+}
+
 // AddPubkey sets an address-pubkey relation
 func (k Keeper) AddPubkey(ctx sdk.Context, pubkey cryptotypes.PubKey) error {
 	bz, err := k.cdc.MarshalInterface(pubkey)
@@ -63,6 +80,181 @@ func (k Keeper) GetPubkey(ctx sdk.Context, a cryptotypes.Address) (cryptotypes.P
 	return pk, k.cdc.UnmarshalInterface(bz, &pk)
 }
 
+// ConsAddrOf returns the consensus address of the validator with the given
+// operator address, resolved through its consensus pubkey in srstaking.
+func (k Keeper) ConsAddrOf(ctx sdk.Context, valAddr sdk.ValAddress) (sdk.ConsAddress, error) {
+	validator := k.Sk.GETValidator(ctx, valAddr)
+	if validator == nil {
+		return nil, sdkerrors.Wrap(types.ErrBadValidatorAddr, valAddr.String())
+	}
+
+	pk, err := validator.ConsPubKey()
+	if err != nil {
+		return nil, err
+	}
+	if pk == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidPubKey, "validator %s has no consensus pubkey", valAddr)
+	}
+
+	return sdk.ConsAddress(pk.Address()), nil
+}
+
+// InitValidator adds the address-pubkey relation of pubkey and creates default
+// signing info starting at startHeight for it. Either both are written or, on
+// error, neither is. It errors if signing info already exists for the address.
+func (k Keeper) InitValidator(ctx sdk.Context, pubkey cryptotypes.PubKey, startHeight int64) error {
+	consAddr := sdk.ConsAddress(pubkey.Address())
+	cacheCtx, write := ctx.CacheContext()
+
+	if err := k.AddPubkey(cacheCtx, pubkey); err != nil {
+		return err
+	}
+	if k.HasValidatorSigningInfo(cacheCtx, consAddr) {
+		return sdkerrors.Wrap(types.ErrPubkeyInUse, consAddr.String())
+	}
+	k.SetValidatorSigningInfo(cacheCtx, consAddr, types.NewValidatorSigningInfo(
+		consAddr, startHeight, 0, time.Unix(0, 0), false, 0,
+	))
+
+	write()
+	return nil
+}
+
+// consAddrValueKeys are the keys of the single value state stored by
+// consensus address, besides the signing info, which RotatePubkey migrates.
+var consAddrValueKeys = []func(sdk.ConsAddress) []byte{
+	types.ValidatorDowntimeStrikesKey,
+	types.ValidatorSlashExemptKey,
+	types.DowntimeJailDurationKey,
+	types.LastSignedHeightKey,
+	types.AuthorityKey,
+	types.PenaltySummaryKey,
+	types.LastUnjailHeightKey,
+	types.UptimeStreakKey,
+	types.LastJailTimeKey,
+}
+
+// RotatePubkey replaces the address-pubkey relation of oldPubkey with one for
+// newPubkey and moves all the state keyed by the old consensus address over
+// to the new one: the signing info, missed block bit array, downtime strikes,
+// slash exemption, authority flag, jail duration override, penalty summary,
+// last signed, unjail and jail markers, uptime streak, slash records and jail
+// events. The rotation is rejected if newPubkey already has a relation, or if
+// its address already has signing info, missed blocks, slash records or any
+// of the other state.
+func (k Keeper) RotatePubkey(ctx sdk.Context, oldPubkey, newPubkey cryptotypes.PubKey) error {
+	oldAddr := sdk.ConsAddress(oldPubkey.Address())
+	newAddr := sdk.ConsAddress(newPubkey.Address())
+
+	if _, err := k.GetPubkey(ctx, oldPubkey.Address()); err != nil {
+		return err
+	}
+	if _, err := k.GetPubkey(ctx, newPubkey.Address()); err == nil || k.hasConsAddrState(ctx, newAddr) {
+		return sdkerrors.Wrap(types.ErrPubkeyInUse, newAddr.String())
+	}
+
+	if err := k.AddPubkey(ctx, newPubkey); err != nil {
+		return err
+	}
+	k.deleteAddrPubkeyRelation(ctx, oldPubkey.Address())
+
+	store := ctx.KVStore(k.storeKey)
+	signInfo, found := k.GetValidatorSigningInfo(ctx, oldAddr)
+	if found {
+		k.IterateValidatorMissedBlockBitArray(ctx, oldAddr, func(index int64, missed bool) (stop bool) {
+			k.SetValidatorMissedBlockBitArray(ctx, newAddr, index, missed)
+			return false
+		})
+		k.clearValidatorMissedBlockBitArray(ctx, oldAddr)
+
+		signInfo.Address = newAddr.String()
+		k.SetValidatorSigningInfo(ctx, newAddr, signInfo)
+		store.Delete(types.ValidatorSigningInfoKey(oldAddr))
+	}
+
+	for _, key := range consAddrValueKeys {
+		if bz := store.Get(key(oldAddr)); bz != nil {
+			store.Set(key(newAddr), bz)
+			store.Delete(key(oldAddr))
+		}
+	}
+	k.moveSlashRecords(ctx, oldAddr, newAddr)
+	k.moveJailEvents(ctx, oldAddr, newAddr)
+
+	k.Logger(ctx).Info("rotated validator pubkey", "old", oldAddr.String(), "new", newAddr.String())
+	return nil
+}
+
+// hasConsAddrState returns if any signing info, missed block, slash record or
+// other state of consAddrValueKeys is stored for a consensus address.
+func (k Keeper) hasConsAddrState(ctx sdk.Context, consAddr sdk.ConsAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	if store.Has(types.ValidatorSigningInfoKey(consAddr)) {
+		return true
+	}
+	for _, key := range consAddrValueKeys {
+		if store.Has(key(consAddr)) {
+			return true
+		}
+	}
+
+	for _, prefix := range [][]byte{types.ValidatorMissedBlockBitArrayPrefixKey(consAddr), types.SlashRecordPrefixKey(consAddr)} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		found := iter.Valid()
+		iter.Close()
+		if found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// moveSlashRecords re-keys the slash records of oldAddr, along with their time
+// index, to newAddr.
+func (k Keeper) moveSlashRecords(ctx sdk.Context, oldAddr, newAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.SlashRecordPrefixKey(oldAddr))
+	var (
+		keys    [][]byte
+		records []types.SlashRecord
+	)
+	for ; iter.Valid(); iter.Next() {
+		var record types.SlashRecord
+		k.cdc.MustUnmarshal(iter.Value(), &record)
+		keys = append(keys, iter.Key())
+		records = append(records, record)
+	}
+	iter.Close()
+
+	for i, record := range records {
+		store.Delete(types.SlashRecordTimeKey(record.Time, keys[i]))
+		store.Delete(keys[i])
+
+		record.Address = newAddr.String()
+		k.SetSlashRecord(ctx, record)
+	}
+}
+
+// moveJailEvents re-keys the jail events of oldAddr to newAddr. The jail
+// event index is keyed by height first, so the whole index is scanned.
+func (k Keeper) moveJailEvents(ctx sdk.Context, oldAddr, newAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.JailEventKeyPrefix)
+	var heights []int64
+	for ; iter.Valid(); iter.Next() {
+		if height, consAddr := types.ParseJailEventKey(iter.Key()); consAddr.Equals(oldAddr) {
+			heights = append(heights, height)
+		}
+	}
+	iter.Close()
+
+	for _, height := range heights {
+		store.Delete(types.JailEventKey(height, oldAddr))
+		store.Set(types.JailEventKey(height, newAddr), []byte{})
+	}
+}
+
 // Jail attempts to jail a validator. The slash is delegated to the srstaking module
 // to make the necessary validator changes.
 func (k Keeper) Jail(ctx sdk.Context, consAddr sdk.ConsAddress) {
@@ -74,6 +266,118 @@ func (k Keeper) Jail(ctx sdk.Context, consAddr sdk.ConsAddress) {
 	)
 
 	k.Sk.Jail(ctx, consAddr)
+	k.recordJailEvent(ctx, consAddr)
+	k.resetUptimeStreak(ctx, consAddr)
+	k.Logger(ctx).Info("jailed validator", "validator", consAddr.String())
+}
+
+// JailQuiet jails a validator through the srstaking module like Jail, but
+// emits no slash event. It is meant only for migrations and replays that
+// re-apply historical jailings, which must not show up in the event stream as
+// new ones. Regular jailing must go through Jail.
+func (k Keeper) JailQuiet(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	k.Sk.Jail(ctx, consAddr)
+	k.resetUptimeStreak(ctx, consAddr)
+	k.setLastJailTime(ctx, consAddr, ctx.BlockHeader().Time)
+	k.Logger(ctx).Debug("quietly jailed validator", "validator", consAddr.String())
+}
+
+// JailFor jails a validator through the srstaking module and keeps it jailed
+// for d from the current block time, regardless of the downtime jail duration
+// param. The emitted slash event carries the duration. It errors if d is not
+// positive, if the validator or its signing info is unknown, or if the
+// validator is already jailed or tombstoned.
+func (k Keeper) JailFor(ctx sdk.Context, consAddr sdk.ConsAddress, d time.Duration) error {
+	if d <= 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidJailDuration, "validator %s: duration %s", consAddr, d)
+	}
+
+	validator := k.Sk.ValidatorByConsAddr(ctx, consAddr)
+	if validator == nil {
+		return sdkerrors.Wrap(types.ErrNoValidatorForAddress, consAddr.String())
+	}
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+	if info.Tombstoned {
+		return sdkerrors.Wrap(types.ErrValidatorTombstoned, consAddr.String())
+	}
+	if validator.IsJailed() {
+		return sdkerrors.Wrap(types.ErrValidatorAlreadyJailed, consAddr.String())
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSlash,
+			sdk.NewAttribute(types.AttributeKeyJailed, consAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyDuration, d.String()),
+		),
+	)
+
+	k.Sk.Jail(ctx, consAddr)
+	k.recordJailEvent(ctx, consAddr)
+	k.resetUptimeStreak(ctx, consAddr)
+	k.JailUntil(ctx, consAddr, ctx.BlockHeader().Time.Add(d))
+	k.Logger(ctx).Info("jailed validator", "validator", consAddr.String(), "duration", d)
+
+	return nil
+}
+
+// JailedBetween returns the jailings indexed between fromHeight and toHeight,
+// both inclusive, ordered by height. Jailings through JailQuiet aren't
+// indexed.
+func (k Keeper) JailedBetween(ctx sdk.Context, fromHeight, toHeight int64) []types.JailEvent {
+	events := []types.JailEvent{}
+	if fromHeight > toHeight {
+		return events
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.JailEventHeightPrefixKey(fromHeight), types.JailEventHeightPrefixKey(toHeight+1))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		height, consAddr := types.ParseJailEventKey(iter.Key())
+		events = append(events, types.JailEvent{ConsAddress: consAddr, Height: height})
+	}
+
+	return events
+}
+
+// recordJailEvent indexes the jailing of a validator at the current height and
+// records the current block time as its last jail time.
+func (k Keeper) recordJailEvent(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.JailEventKey(ctx.BlockHeight(), consAddr), []byte{})
+	k.setLastJailTime(ctx, consAddr, ctx.BlockHeader().Time)
+}
+
+// getLastJailTime returns the block time a validator was last jailed at, if
+// its jailing was recorded.
+func (k Keeper) getLastJailTime(ctx sdk.Context, consAddr sdk.ConsAddress) (time.Time, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.LastJailTimeKey(consAddr))
+	if bz == nil {
+		return time.Time{}, false
+	}
+
+	var ts gogotypes.Timestamp
+	k.cdc.MustUnmarshal(bz, &ts)
+	jailTime, err := gogotypes.TimestampFromProto(&ts)
+	if err != nil {
+		panic(err)
+	}
+	return jailTime, true
+}
+
+func (k Keeper) setLastJailTime(ctx sdk.Context, consAddr sdk.ConsAddress, jailTime time.Time) {
+	ts, err := gogotypes.TimestampProto(jailTime)
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.LastJailTimeKey(consAddr), k.cdc.MustMarshal(ts))
 }
 
 func (k Keeper) deleteAddrPubkeyRelation(ctx sdk.Context, addr cryptotypes.Address) {
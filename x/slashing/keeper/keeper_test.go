@@ -0,0 +1,248 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"gea-poa/x/slashing/types"
+)
+
+func TestJailLogs(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var buf bytes.Buffer
+	ctx = ctx.WithLogger(log.NewTMLogger(log.NewSyncWriter(&buf)))
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	k.Jail(ctx, consAddr)
+
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+	require.Contains(t, buf.String(), "jailed validator")
+	require.Contains(t, buf.String(), consAddr.String())
+	require.Contains(t, buf.String(), "module=x/slashing")
+}
+
+func TestJailedBetween(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var events []types.JailEvent
+	for _, height := range []int64{5, 10, 10, 20, 30} {
+		ctx = ctx.WithBlockHeight(height)
+		consAddr := sdk.ConsAddress(sk.addValidator().Address())
+		k.Jail(ctx, consAddr)
+		events = append(events, types.JailEvent{ConsAddress: consAddr, Height: height})
+	}
+	// quiet jailings aren't indexed
+	k.JailQuiet(ctx.WithBlockHeight(15), sdk.ConsAddress(sk.addValidator().Address()))
+
+	// same height jailings are ordered by address
+	expected := events[1:4]
+	if bytes.Compare(expected[0].ConsAddress, expected[1].ConsAddress) > 0 {
+		expected[0], expected[1] = expected[1], expected[0]
+	}
+	require.Equal(t, expected, k.JailedBetween(ctx, 10, 20))
+	require.Len(t, k.JailedBetween(ctx, 0, 100), 5)
+	require.Empty(t, k.JailedBetween(ctx, 11, 19))
+	require.Empty(t, k.JailedBetween(ctx, 20, 10))
+}
+
+func TestJailQuiet(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	k.JailQuiet(ctx, consAddr)
+
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestJailFor(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+	unknown := sdk.ConsAddress(ed25519.GenPrivKey().PubKey().Address())
+	require.ErrorIs(t, k.JailFor(ctx, unknown, 0), types.ErrInvalidJailDuration)
+	require.ErrorIs(t, k.JailFor(ctx, unknown, -time.Minute), types.ErrInvalidJailDuration)
+	require.ErrorIs(t, k.JailFor(ctx, unknown, 10*time.Minute), types.ErrNoValidatorForAddress)
+
+	consAddr := sdk.ConsAddress(sk.addValidator().Address())
+	require.ErrorIs(t, k.JailFor(ctx, consAddr, 10*time.Minute), types.ErrNoSigningInfoFound)
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 0, time.Unix(0, 0), false, 0))
+	require.NoError(t, k.JailFor(ctx, consAddr, 10*time.Minute))
+
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, ctx.BlockTime().Add(10*time.Minute), info.JailedUntil)
+	require.Len(t, k.JailedBetween(ctx, ctx.BlockHeight(), ctx.BlockHeight()), 1)
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeSlash, events[0].Type)
+	require.Equal(t, types.AttributeKeyDuration, string(events[0].Attributes[1].Key))
+	require.Equal(t, "10m0s", string(events[0].Attributes[1].Value))
+
+	// jailing again would shorten or extend the running jail period
+	require.ErrorIs(t, k.JailFor(ctx, consAddr, time.Hour), types.ErrValidatorAlreadyJailed)
+	info, _ = k.GetValidatorSigningInfo(ctx, consAddr)
+	require.Equal(t, ctx.BlockTime().Add(10*time.Minute), info.JailedUntil)
+
+	tombstoned := sdk.ConsAddress(sk.addValidator().Address())
+	k.SetValidatorSigningInfo(ctx, tombstoned, types.NewValidatorSigningInfo(tombstoned, 1, 0, time.Unix(0, 0), true, 0))
+	require.ErrorIs(t, k.JailFor(ctx, tombstoned, 10*time.Minute), types.ErrValidatorTombstoned)
+	require.False(t, sk.byConsAddr(tombstoned).IsJailed())
+}
+
+func TestConsAddrOf(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	pk := sk.addValidator()
+	consAddr, err := k.ConsAddrOf(ctx, sdk.ValAddress(pk.Address()))
+	require.NoError(t, err)
+	require.Equal(t, sdk.ConsAddress(pk.Address()), consAddr)
+
+	_, err = k.ConsAddrOf(ctx, sdk.ValAddress("unknown"))
+	require.ErrorIs(t, err, types.ErrBadValidatorAddr)
+
+	sk.validators = append(sk.validators, &mockValidator{operator: sdk.ValAddress("nopubkey")})
+	_, err = k.ConsAddrOf(ctx, sdk.ValAddress("nopubkey"))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidPubKey)
+}
+
+func TestInitValidator(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	require.NoError(t, k.InitValidator(ctx, pk, 7))
+
+	_, err := k.GetPubkey(ctx, pk.Address())
+	require.NoError(t, err)
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, int64(7), info.StartHeight)
+
+	// signing info already exists, so the pubkey relation must be rolled back
+	failPk := sk.addValidator()
+	failAddr := sdk.ConsAddress(failPk.Address())
+	k.SetValidatorSigningInfo(ctx, failAddr, types.NewValidatorSigningInfo(failAddr, 3, 0, ctx.BlockTime(), false, 0))
+
+	err = k.InitValidator(ctx, failPk, 7)
+	require.ErrorIs(t, err, types.ErrPubkeyInUse)
+
+	_, err = k.GetPubkey(ctx, failPk.Address())
+	require.Error(t, err)
+	info, found = k.GetValidatorSigningInfo(ctx, failAddr)
+	require.True(t, found)
+	require.Equal(t, int64(3), info.StartHeight)
+}
+
+func TestRotatePubkey(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	oldPk, newPk, usedPk := sk.addValidator(), ed25519.GenPrivKey().PubKey(), sk.addValidator()
+	oldAddr, newAddr := sdk.ConsAddress(oldPk.Address()), sdk.ConsAddress(newPk.Address())
+	require.NoError(t, k.AddPubkey(ctx, oldPk))
+	require.NoError(t, k.AddPubkey(ctx, usedPk))
+	k.SetValidatorSigningInfo(ctx, oldAddr, types.NewValidatorSigningInfo(oldAddr, 5, 3, ctx.BlockTime(), false, 1))
+	k.SetValidatorMissedBlockBitArray(ctx, oldAddr, 2, true)
+
+	err := k.RotatePubkey(ctx, oldPk, usedPk)
+	require.ErrorIs(t, err, types.ErrPubkeyInUse)
+
+	require.NoError(t, k.RotatePubkey(ctx, oldPk, newPk))
+
+	_, err = k.GetPubkey(ctx, oldPk.Address())
+	require.Error(t, err)
+	pk, err := k.GetPubkey(ctx, newPk.Address())
+	require.NoError(t, err)
+	require.True(t, newPk.Equals(pk))
+
+	require.False(t, k.HasValidatorSigningInfo(ctx, oldAddr))
+	info, found := k.GetValidatorSigningInfo(ctx, newAddr)
+	require.True(t, found)
+	require.Equal(t, newAddr.String(), info.Address)
+	require.Equal(t, int64(5), info.StartHeight)
+	require.Equal(t, int64(1), info.MissedBlocksCounter)
+	require.True(t, k.GetValidatorMissedBlockBitArray(ctx, newAddr, 2))
+	require.Empty(t, k.GetValidatorMissedBlocks(ctx, oldAddr))
+}
+
+func TestRotatePubkeyMigratesValidatorState(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	params := k.GetParams(ctx)
+	params.UnjailCooldownBlocks = 100
+	params.MaxJailDuration = 24 * time.Hour
+	k.SetParams(ctx, params)
+
+	oldPk, newPk := sk.addValidator(), ed25519.GenPrivKey().PubKey()
+	oldAddr, newAddr := sdk.ConsAddress(oldPk.Address()), sdk.ConsAddress(newPk.Address())
+	require.NoError(t, k.AddPubkey(ctx, oldPk))
+	k.SetValidatorSigningInfo(ctx, oldAddr, types.NewValidatorSigningInfo(oldAddr, 1, 0, time.Unix(0, 0), false, 0))
+
+	k.SetDowntimeStrikes(ctx, oldAddr, 3)
+	k.SetSlashExempt(ctx, oldAddr)
+	k.SetAuthority(ctx, oldAddr)
+	k.SetDowntimeJailDurationOverride(ctx, oldAddr, time.Hour)
+	k.SetSlashRecord(ctx, types.SlashRecord{Address: oldAddr.String(), Height: 1, Reason: types.InfractionDowntime, Fraction: sdk.NewDecWithPrec(1, 2), Power: 10, Time: ctx.BlockTime()})
+	k.Jail(ctx, oldAddr)
+	jailTime := ctx.BlockTime()
+	require.NoError(t, k.UnjailAfterJailPeriod(ctx, oldAddr))
+
+	ctx = ctx.WithBlockHeight(2).WithBlockTime(jailTime.Add(time.Hour))
+	k.HandleValidatorSignature(ctx, oldPk.Address(), 1, false)
+	ctx = ctx.WithBlockHeight(3)
+	k.HandleValidatorSignature(ctx, oldPk.Address(), 1, true)
+
+	require.NoError(t, k.RotatePubkey(ctx, oldPk, newPk))
+	// srstaking follows the rotation
+	sk.byConsAddr(oldAddr).pubkey = newPk
+
+	for _, addr := range []sdk.ConsAddress{oldAddr, newAddr} {
+		moved := addr.Equals(newAddr)
+		require.Equal(t, moved, k.DowntimeStrikes(ctx, addr) == 3)
+		require.Equal(t, moved, k.IsSlashExempt(ctx, addr))
+		require.Equal(t, moved, k.IsAuthority(ctx, addr))
+		_, found := k.GetDowntimeJailDurationOverride(ctx, addr)
+		require.Equal(t, moved, found)
+		_, err := k.LastSignedHeight(ctx, addr)
+		require.Equal(t, moved, err == nil)
+		require.Equal(t, moved, len(k.GetSlashRecords(ctx, addr)) == 1)
+	}
+
+	summary, err := k.PenaltyHistory(ctx, newAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), summary.MissedBlocks)
+	require.Equal(t, newAddr.String(), k.GetSlashRecords(ctx, newAddr)[0].Address)
+	require.Equal(t, []types.JailEvent{{ConsAddress: newAddr, Height: 1}}, k.JailedBetween(ctx, 0, 10))
+
+	leader, streak := k.LongestUptimeStreak(ctx)
+	require.Equal(t, newAddr, leader)
+	require.Equal(t, int64(1), streak)
+
+	// the unjail cooldown and the removal countdown carry over
+	sk.Jail(ctx, newAddr)
+	require.ErrorIs(t, k.UnjailAfterJailPeriod(ctx, newAddr), types.ErrUnjailCooldown)
+	remaining, _, err := k.TimeUntilRemoval(ctx, newAddr)
+	require.NoError(t, err)
+	require.Equal(t, 23*time.Hour, remaining)
+
+	// an address with leftover state can't be rotated to
+	otherPk := sk.addValidator()
+	require.NoError(t, k.AddPubkey(ctx, otherPk))
+	k.SetDowntimeStrikes(ctx, oldAddr, 1)
+	require.ErrorIs(t, k.RotatePubkey(ctx, otherPk, oldPk), types.ErrPubkeyInUse)
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	v043 "gea-poa/x/slashing/legacy/v043"
+	v3 "gea-poa/x/slashing/legacy/v3"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -19,3 +20,8 @@ func NewMigrator(keeper Keeper) Migrator {
 func (m Migrator) Migrate1to2(ctx sdk.Context) error {
 	return v043.MigrateStore(ctx, m.keeper.storeKey)
 }
+
+// Migrate2to3 migrates from version 2 to 3.
+func (m Migrator) Migrate2to3(ctx sdk.Context) error {
+	return v3.MigrateParams(ctx, m.keeper.paramspace)
+}
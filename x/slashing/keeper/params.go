@@ -1,9 +1,13 @@
 package keeper
 
 import (
+	"bytes"
+	"fmt"
+	"reflect"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 	//"github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"gea-poa/x/slashing/types"
 )
@@ -31,13 +35,117 @@ func (k Keeper) DowntimeJailDuration(ctx sdk.Context) (res time.Duration) {
 	return
 }
 
+// SlashFractionDoubleSign - fraction of power slashed in case of double sign
+func (k Keeper) SlashFractionDoubleSign(ctx sdk.Context) (res sdk.Dec) {
+	k.paramspace.Get(ctx, types.KeySlashFractionDoubleSign, &res)
+	return
+}
+
+// SlashFractionDowntime - fraction of power slashed for downtime
+func (k Keeper) SlashFractionDowntime(ctx sdk.Context) (res sdk.Dec) {
+	k.paramspace.Get(ctx, types.KeySlashFractionDowntime, &res)
+	return
+}
+
+// UnjailCooldownBlocks - blocks to wait between two unjails of a validator
+func (k Keeper) UnjailCooldownBlocks(ctx sdk.Context) (res int64) {
+	k.paramspace.Get(ctx, types.KeyUnjailCooldownBlocks, &res)
+	return
+}
+
+// MaxJailDuration - time a validator can stay jailed before being removable
+func (k Keeper) MaxJailDuration(ctx sdk.Context) (res time.Duration) {
+	k.paramspace.Get(ctx, types.KeyMaxJailDuration, &res)
+	return
+}
+
 // GetParams returns the total set of slashing parameters.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
 	k.paramspace.GetParamSet(ctx, &params)
 	return params
 }
 
-// SetParams sets the slashing parameters to the param space.
+// ParamsAsMap returns every slashing param keyed by its param store key, with
+// its value formatted with fmt, e.g. "SignedBlocksWindow": "100".
+func (k Keeper) ParamsAsMap(ctx sdk.Context) map[string]string {
+	params := k.GetParams(ctx)
+	pairs := params.ParamSetPairs()
+
+	m := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		m[string(pair.Key)] = formatParamValue(pair)
+	}
+	return m
+}
+
+// formatParamValue formats the value of a param set pair with fmt.
+func formatParamValue(pair paramtypes.ParamSetPair) string {
+	return fmt.Sprint(reflect.ValueOf(pair.Value).Elem().Interface())
+}
+
+// ValidateParams checks every param against the validation of its param set
+// pair, returning the first error: the signed blocks window must be positive,
+// the min signed per window and slash fractions within [0, 1], the downtime
+// jail duration positive and the unjail cooldown and max jail duration
+// non-negative. It lets governance pre-check params before SetParams.
+func (k Keeper) ValidateParams(p types.Params) error {
+	for _, pair := range p.ParamSetPairs() {
+		if err := pair.ValidatorFn(reflect.ValueOf(pair.Value).Elem().Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetParams sets the slashing parameters to the param space and applies them
+// with ApplyParamChanges. It panics on params failing ValidateParams.
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	if err := k.ValidateParams(params); err != nil {
+		panic(err)
+	}
+
 	k.paramspace.SetParamSet(ctx, &params)
+	k.ApplyParamChanges(ctx)
+}
+
+// ApplyParamChanges applies the params that changed since they were last
+// applied: it emits an event listing the keys of the changed params, and
+// shrinking the signed blocks window truncates the missed block bit arrays to
+// it, see truncateMissedBlockBitArrays. It runs in SetParams and in
+// BeginBlocker, which catches the params written to the subspace directly,
+// e.g. by a governance param change proposal. The first call only records the
+// params.
+func (k Keeper) ApplyParamChanges(ctx sdk.Context) {
+	params := k.GetParams(ctx)
+	bz := k.cdc.MustMarshal(&params)
+
+	store := ctx.KVStore(k.storeKey)
+	appliedBz := store.Get(types.AppliedParamsKey)
+	if bytes.Equal(appliedBz, bz) {
+		return
+	}
+	store.Set(types.AppliedParamsKey, bz)
+	if appliedBz == nil {
+		return
+	}
+
+	var applied types.Params
+	k.cdc.MustUnmarshal(appliedBz, &applied)
+	if params.SignedBlocksWindow < applied.SignedBlocksWindow {
+		k.truncateMissedBlockBitArrays(ctx, params.SignedBlocksWindow)
+	}
+
+	appliedPairs := applied.ParamSetPairs()
+	var attrs []sdk.Attribute
+	for i, pair := range params.ParamSetPairs() {
+		if formatParamValue(pair) != formatParamValue(appliedPairs[i]) {
+			attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyParam, string(pair.Key)))
+		}
+	}
+
+	if len(attrs) == 0 {
+		return
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeParamsUpdated, attrs...))
 }
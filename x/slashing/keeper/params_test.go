@@ -0,0 +1,177 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing"
+	"gea-poa/x/slashing/types"
+)
+
+func TestSetParamsEvent(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+
+	params := k.GetParams(ctx)
+	params.DowntimeJailDuration = time.Hour
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.SetParams(ctx, params)
+	require.Equal(t, time.Hour, k.DowntimeJailDuration(ctx))
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeParamsUpdated, events[0].Type)
+	require.Len(t, events[0].Attributes, 1)
+	require.Equal(t, types.AttributeKeyParam, string(events[0].Attributes[0].Key))
+	require.Equal(t, string(types.KeyDowntimeJailDuration), string(events[0].Attributes[0].Value))
+
+	// writing the same params again changes nothing
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.SetParams(ctx, params)
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestParamChangeProposalEvent(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+
+	// param change proposals write the subspace directly, BeginBlocker applies it
+	k.ParamsSubspace().Set(ctx, types.KeyUnjailCooldownBlocks, int64(10))
+	k.ParamsSubspace().Set(ctx, types.KeySlashFractionDowntime, sdk.NewDecWithPrec(1, 2))
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeParamsUpdated, events[0].Type)
+	require.Equal(t, []abci.EventAttribute{
+		sdk.NewAttribute(types.AttributeKeyParam, string(types.KeySlashFractionDowntime)).ToKVPair(),
+		sdk.NewAttribute(types.AttributeKeyParam, string(types.KeyUnjailCooldownBlocks)).ToKVPair(),
+	}, events[0].Attributes)
+
+	// applied changes are reported once
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestValidateParams(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	require.NoError(t, k.ValidateParams(types.DefaultParams()))
+
+	for _, tc := range []struct {
+		modify func(*types.Params)
+		err    string
+	}{
+		{func(p *types.Params) { p.SignedBlocksWindow = 0 }, "signed blocks window must be positive: 0"},
+		{func(p *types.Params) { p.MinSignedPerWindow = sdk.NewDec(2) }, "min signed per window too large: 2.000000000000000000"},
+		{func(p *types.Params) { p.MinSignedPerWindow = sdk.NewDec(-1) }, "min signed per window cannot be negative: -1.000000000000000000"},
+		{func(p *types.Params) { p.SlashFractionDoubleSign = sdk.NewDecWithPrec(11, 1) }, "double sign slash fraction too large: 1.100000000000000000"},
+		{func(p *types.Params) { p.SlashFractionDowntime = sdk.NewDecWithPrec(-1, 1) }, "downtime slash fraction cannot be negative: -0.100000000000000000"},
+		{func(p *types.Params) { p.DowntimeJailDuration = -time.Second }, "downtime jail duration must be positive: -1s"},
+		{func(p *types.Params) { p.UnjailCooldownBlocks = -1 }, "unjail cooldown blocks cannot be negative: -1"},
+		{func(p *types.Params) { p.MaxJailDuration = -time.Minute }, "max jail duration cannot be negative: -1m0s"},
+	} {
+		params := types.DefaultParams()
+		tc.modify(&params)
+		require.EqualError(t, k.ValidateParams(params), tc.err)
+		require.Panics(t, func() { k.SetParams(ctx, params) }, tc.err)
+	}
+
+	// invalid params are never stored
+	require.Equal(t, types.DefaultParams(), k.GetParams(ctx))
+}
+
+func TestParamsAsMap(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+
+	params := k.GetParams(ctx)
+	params.DowntimeJailDuration = time.Hour
+	k.SetParams(ctx, params)
+
+	m := k.ParamsAsMap(ctx)
+	for _, key := range [][]byte{
+		types.KeySignedBlocksWindow, types.KeyMinSignedPerWindow, types.KeyDowntimeJailDuration,
+		types.KeySlashFractionDoubleSign, types.KeySlashFractionDowntime, types.KeyUnjailCooldownBlocks,
+		types.KeyMaxJailDuration,
+	} {
+		require.Contains(t, m, string(key))
+	}
+	require.Len(t, m, len(params.ParamSetPairs()))
+
+	require.Equal(t, "100", m[string(types.KeySignedBlocksWindow)])
+	require.Equal(t, "0.500000000000000000", m[string(types.KeyMinSignedPerWindow)])
+	require.Equal(t, "1h0m0s", m[string(types.KeyDowntimeJailDuration)])
+}
+
+func TestParamsSubspace(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+
+	subspace := k.ParamsSubspace()
+	require.True(t, subspace.HasKeyTable())
+
+	var window int64
+	subspace.Get(ctx, types.KeySignedBlocksWindow, &window)
+	require.Equal(t, types.DefaultSignedBlocksWindow, window)
+}
+
+func TestSetParamsShrinkWindowTruncatesMissedBlocks(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	consAddr := sdk.ConsAddress("validator")
+
+	// missed blocks at index 1, 5 and 8 of the default 100 block window
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 9, ctx.BlockTime(), false, 3))
+	for _, index := range []int64{1, 5, 8} {
+		k.SetValidatorMissedBlockBitArray(ctx, consAddr, index, true)
+	}
+	k.SetValidatorMissedBlockBitArray(ctx, consAddr, 2, false)
+
+	params := k.GetParams(ctx)
+	params.SignedBlocksWindow = 6
+	k.SetParams(ctx, params)
+
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.Equal(t, int64(2), info.MissedBlocksCounter)
+	require.Equal(t, []types.MissedBlock{
+		types.NewMissedBlock(1, true),
+		types.NewMissedBlock(2, false),
+		types.NewMissedBlock(5, true),
+	}, k.GetValidatorMissedBlocks(ctx, consAddr))
+
+	// growing the window again keeps the counter
+	params.SignedBlocksWindow = 100
+	k.SetParams(ctx, params)
+	info, _ = k.GetValidatorSigningInfo(ctx, consAddr)
+	require.Equal(t, int64(2), info.MissedBlocksCounter)
+}
+
+func TestParamChangeProposalShrinkWindowTruncatesMissedBlocks(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	consAddr := sdk.ConsAddress("validator")
+
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 9, ctx.BlockTime(), false, 2))
+	for _, index := range []int64{1, 8} {
+		k.SetValidatorMissedBlockBitArray(ctx, consAddr, index, true)
+	}
+
+	// param change proposals write the subspace directly, BeginBlocker applies it
+	k.ParamsSubspace().Set(ctx, types.KeySignedBlocksWindow, int64(6))
+	info, _ := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.Equal(t, int64(2), info.MissedBlocksCounter)
+
+	slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	info, _ = k.GetValidatorSigningInfo(ctx, consAddr)
+	require.Equal(t, int64(1), info.MissedBlocksCounter)
+	require.Equal(t, []types.MissedBlock{types.NewMissedBlock(1, true)}, k.GetValidatorMissedBlocks(ctx, consAddr))
+
+	// already applied params are not applied again
+	k.SetValidatorMissedBlockBitArray(ctx, consAddr, 8, true)
+	slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	require.True(t, k.GetValidatorMissedBlockBitArray(ctx, consAddr, 8))
+}
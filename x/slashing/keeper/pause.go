@@ -0,0 +1,49 @@
+package keeper
+
+import (
+	"strconv"
+
+	gogotypes "github.com/gogo/protobuf/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+// SetDowntimeJailingPaused pauses or resumes downtime jailing network-wide,
+// e.g. for a planned maintenance window. While paused, missed blocks are still
+// recorded, so validators past the threshold are jailed once jailing resumes.
+// An event is emitted when the flag changes.
+func (k Keeper) SetDowntimeJailingPaused(ctx sdk.Context, paused bool) {
+	if k.IsDowntimeJailingPaused(ctx) == paused {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	if paused {
+		store.Set(types.DowntimeJailingPausedKey, k.cdc.MustMarshal(&gogotypes.BoolValue{Value: true}))
+	} else {
+		store.Delete(types.DowntimeJailingPausedKey)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeDowntimeJailing,
+			sdk.NewAttribute(types.AttributeKeyPaused, strconv.FormatBool(paused)),
+		),
+	)
+	k.Logger(ctx).Info("updated downtime jailing pause", "paused", paused)
+}
+
+// IsDowntimeJailingPaused returns if downtime jailing is paused.
+func (k Keeper) IsDowntimeJailingPaused(ctx sdk.Context) bool {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.DowntimeJailingPausedKey)
+	if bz == nil {
+		return false
+	}
+
+	var paused gogotypes.BoolValue
+	k.cdc.MustUnmarshal(bz, &paused)
+	return paused.Value
+}
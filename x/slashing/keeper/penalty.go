@@ -0,0 +1,40 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"gea-poa/x/slashing/types"
+)
+
+// PenaltyHistory returns the lifetime penalty counters of a validator: how
+// often it was jailed for downtime or tombstoned and how many blocks it
+// missed. Unlike the signing info counters, they are never reset.
+func (k Keeper) PenaltyHistory(ctx sdk.Context, consAddr sdk.ConsAddress) (types.PenaltySummary, error) {
+	if !k.HasValidatorSigningInfo(ctx, consAddr) {
+		return types.PenaltySummary{}, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	return k.getPenaltySummary(ctx, consAddr), nil
+}
+
+func (k Keeper) getPenaltySummary(ctx sdk.Context, consAddr sdk.ConsAddress) (summary types.PenaltySummary) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.PenaltySummaryKey(consAddr))
+	if bz == nil {
+		return summary
+	}
+
+	k.cdc.MustUnmarshal(bz, &summary)
+	return summary
+}
+
+// updatePenaltySummary applies update to the lifetime penalty counters of a
+// validator.
+func (k Keeper) updatePenaltySummary(ctx sdk.Context, consAddr sdk.ConsAddress, update func(summary *types.PenaltySummary)) {
+	summary := k.getPenaltySummary(ctx, consAddr)
+	update(&summary)
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.PenaltySummaryKey(consAddr), k.cdc.MustMarshal(&summary))
+}
@@ -0,0 +1,39 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+func TestPenaltyHistory(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	_, err := k.PenaltyHistory(ctx, sdk.ConsAddress("unknown"))
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	summary, err := k.PenaltyHistory(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, types.PenaltySummary{}, summary)
+
+	// get past the first window while signing, then miss enough to be jailed
+	for i := 0; i < 10; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	}
+	ctx = missBlocks(ctx, k, pk, 6)
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+
+	// the signing info counter was reset by the jailing, the lifetime one isn't
+	k.Tombstone(ctx, consAddr)
+	summary, err = k.PenaltyHistory(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, types.PenaltySummary{DowntimeJails: 1, Tombstones: 1, MissedBlocks: 6}, summary)
+}
@@ -1,11 +1,16 @@
 package keeper
 
 import (
+	"encoding/binary"
+	"fmt"
+	"sort"
 	"time"
 
 	gogotypes "github.com/gogo/protobuf/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/srstaking/types"
 	//"github.com/cosmos/cosmos-sdk/x/slashing/types"
 	"gea-poa/x/slashing/types"
 )
@@ -31,6 +36,299 @@ func (k Keeper) HasValidatorSigningInfo(ctx sdk.Context, consAddr sdk.ConsAddres
 	return ok
 }
 
+// HasEverSigned returns if a validator has signed any block since its signing
+// window started, i.e. if its last signed height is recorded at or after its
+// start height. The missed blocks counter can't tell, as resetting missed
+// blocks and truncating or wrapping around the window lower it while the
+// index offset keeps counting.
+func (k Keeper) HasEverSigned(ctx sdk.Context, consAddr sdk.ConsAddress) (bool, error) {
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return false, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	height, err := k.LastSignedHeight(ctx, consAddr)
+	if err != nil {
+		// no signature was ever recorded
+		return false, nil
+	}
+	return height >= info.StartHeight, nil
+}
+
+// GetSigningStartHeight returns the height at which the signing window of a
+// validator started.
+func (k Keeper) GetSigningStartHeight(ctx sdk.Context, consAddr sdk.ConsAddress) (int64, error) {
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return 0, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	return info.StartHeight, nil
+}
+
+// GetIndexOffset returns the position of a validator within its signing window,
+// as stored in its signing info.
+func (k Keeper) GetIndexOffset(ctx sdk.Context, consAddr sdk.ConsAddress) (int64, error) {
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return 0, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	return info.IndexOffset, nil
+}
+
+// LastSignedHeight returns the last height at which a validator signed a
+// block, erroring if no signature was ever recorded for it.
+func (k Keeper) LastSignedHeight(ctx sdk.Context, consAddr sdk.ConsAddress) (int64, error) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.LastSignedHeightKey(consAddr))
+	if bz == nil {
+		return 0, sdkerrors.Wrapf(sdkerrors.ErrNotFound, "no signature recorded for %s", consAddr)
+	}
+
+	var height gogotypes.Int64Value
+	k.cdc.MustUnmarshal(bz, &height)
+	return height.Value, nil
+}
+
+func (k Keeper) setLastSignedHeight(ctx sdk.Context, consAddr sdk.ConsAddress, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&gogotypes.Int64Value{Value: height})
+	store.Set(types.LastSignedHeightKey(consAddr), bz)
+}
+
+// LongestUptimeStreak returns the validator that signed the most consecutive
+// blocks up to now, along with the number of blocks. Ties go to the lowest
+// address. Streaks are reset on jailing, tombstoning and key rotation, and
+// validators jailed or no longer known to srstaking, which stop voting without
+// missing a block, are skipped. It returns a nil address and 0 if no validator
+// is on a streak.
+func (k Keeper) LongestUptimeStreak(ctx sdk.Context) (sdk.ConsAddress, int64) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.UptimeStreakKeyPrefix)
+	defer iter.Close()
+
+	var (
+		leader  sdk.ConsAddress
+		longest int64
+	)
+	for ; iter.Valid(); iter.Next() {
+		var streak gogotypes.Int64Value
+		k.cdc.MustUnmarshal(iter.Value(), &streak)
+		if streak.Value <= longest {
+			continue
+		}
+
+		consAddr := types.UptimeStreakAddress(iter.Key())
+		if validator := k.Sk.ValidatorByConsAddr(ctx, consAddr); validator == nil || validator.IsJailed() {
+			continue
+		}
+		leader = consAddr
+		longest = streak.Value
+	}
+
+	return leader, longest
+}
+
+func (k Keeper) incrementUptimeStreak(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	var streak gogotypes.Int64Value
+	if bz := store.Get(types.UptimeStreakKey(consAddr)); bz != nil {
+		k.cdc.MustUnmarshal(bz, &streak)
+	}
+
+	streak.Value++
+	store.Set(types.UptimeStreakKey(consAddr), k.cdc.MustMarshal(&streak))
+}
+
+func (k Keeper) resetUptimeStreak(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.UptimeStreakKey(consAddr))
+}
+
+// GetAllValidatorSigningInfos returns every validator signing info paired with
+// its bech32 consensus address, as stored in genesis, sorted by address.
+func (k Keeper) GetAllValidatorSigningInfos(ctx sdk.Context) []types.SigningInfo {
+	infos := []types.SigningInfo{}
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info types.ValidatorSigningInfo) (stop bool) {
+		infos = append(infos, types.SigningInfo{
+			Address:              address.String(),
+			ValidatorSigningInfo: info,
+		})
+		return false
+	})
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Address < infos[j].Address
+	})
+
+	return infos
+}
+
+// ValidatorsByMissedBlocks returns up to limit validators with signing info
+// sorted by missed blocks counter descending, every validator for a
+// non-positive limit. Validators sharing a counter keep the store order.
+func (k Keeper) ValidatorsByMissedBlocks(ctx sdk.Context, limit int) []types.ValidatorMiss {
+	misses := []types.ValidatorMiss{}
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info types.ValidatorSigningInfo) (stop bool) {
+		misses = append(misses, types.ValidatorMiss{
+			ConsAddress:  address,
+			MissedBlocks: info.MissedBlocksCounter,
+			Tombstoned:   info.Tombstoned,
+		})
+		return false
+	})
+
+	sort.SliceStable(misses, func(i, j int) bool {
+		return misses[i].MissedBlocks > misses[j].MissedBlocks
+	})
+
+	if limit > 0 && len(misses) > limit {
+		misses = misses[:limit]
+	}
+	return misses
+}
+
+// SnapshotSigningInfos captures the signing info of every validator at the
+// current height, see types.CompareSigningSnapshots.
+func (k Keeper) SnapshotSigningInfos(ctx sdk.Context) types.SigningInfoSnapshot {
+	return types.SigningInfoSnapshot{
+		Height: ctx.BlockHeight(),
+		Infos:  k.GetAllValidatorSigningInfos(ctx),
+	}
+}
+
+// AnyValidatorJailed returns whether any validator with signing info is
+// currently jailed in srstaking. Iteration stops at the first jailed validator.
+func (k Keeper) AnyValidatorJailed(ctx sdk.Context) bool {
+	jailed := false
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, _ types.ValidatorSigningInfo) (stop bool) {
+		validator := k.Sk.ValidatorByConsAddr(ctx, address)
+		jailed = validator != nil && validator.IsJailed()
+		return jailed
+	})
+
+	return jailed
+}
+
+// JailedFraction returns the fraction of the srstaking validator set that is
+// currently jailed, zero if the set is empty.
+func (k Keeper) JailedFraction(ctx sdk.Context, sk types.StakingKeeper) sdk.Dec {
+	var total, jailed int64
+	sk.IterateValidators(ctx, func(_ int64, validator stakingtypes.ValidatorI) (stop bool) {
+		total++
+		if validator.IsJailed() {
+			jailed++
+		}
+		return false
+	})
+
+	if total == 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDec(jailed).QuoInt64(total)
+}
+
+// NetworkUptime returns the share of blocks signed by the unjailed validators
+// of the srstaking set over the current signed blocks window: the sum of
+// (window - missed blocks) divided by (validators * window). Validators without
+// signing info are left out. It is zero if no validator is counted.
+func (k Keeper) NetworkUptime(ctx sdk.Context, sk types.StakingKeeper) sdk.Dec {
+	window := k.SignedBlocksWindow(ctx)
+
+	var count, signed int64
+	sk.IterateValidators(ctx, func(_ int64, validator stakingtypes.ValidatorI) (stop bool) {
+		if validator.IsJailed() {
+			return false
+		}
+		consAddr, err := validator.GetConsAddr()
+		if err != nil {
+			return false
+		}
+		info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+		if !found {
+			return false
+		}
+
+		count++
+		signed += window - info.MissedBlocksCounter
+		return false
+	})
+
+	if count == 0 || window == 0 {
+		return sdk.ZeroDec()
+	}
+	return sdk.NewDec(signed).QuoInt64(count * window)
+}
+
+// ValidatorsWithoutSigningInfo returns the consensus addresses of the
+// validators in the srstaking validator set that have no signing info, e.g.
+// validators created before signing info was tracked. Validators whose
+// consensus address can't be resolved are skipped.
+func (k Keeper) ValidatorsWithoutSigningInfo(ctx sdk.Context, sk types.StakingKeeper) []sdk.ConsAddress {
+	addrs := []sdk.ConsAddress{}
+	sk.IterateValidators(ctx, func(_ int64, validator stakingtypes.ValidatorI) (stop bool) {
+		consAddr, err := validator.GetConsAddr()
+		if err != nil {
+			return false
+		}
+
+		if !k.HasValidatorSigningInfo(ctx, consAddr) {
+			addrs = append(addrs, consAddr)
+		}
+		return false
+	})
+
+	return addrs
+}
+
+// DetectDesync cross-references the unjailed validators of the srstaking set
+// against their signing infos and reports every validator whose liveness
+// tracking is broken: no signing info, signing info stored under another
+// address, or a tombstone srstaking doesn't reflect. It doesn't modify state.
+func (k Keeper) DetectDesync(ctx sdk.Context, sk types.StakingKeeper) []types.DesyncReport {
+	reports := []types.DesyncReport{}
+	sk.IterateValidators(ctx, func(_ int64, validator stakingtypes.ValidatorI) (stop bool) {
+		if validator.IsJailed() {
+			return false
+		}
+		consAddr, err := validator.GetConsAddr()
+		if err != nil {
+			return false
+		}
+
+		info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+		switch {
+		case !found:
+			reports = append(reports, types.DesyncReport{ConsAddress: consAddr, Reason: types.DesyncMissingSigningInfo})
+		case info.Address != consAddr.String():
+			reports = append(reports, types.DesyncReport{ConsAddress: consAddr, Reason: types.DesyncAddressMismatch})
+		case info.Tombstoned:
+			reports = append(reports, types.DesyncReport{ConsAddress: consAddr, Reason: types.DesyncTombstonedUnjailed})
+		}
+		return false
+	})
+
+	return reports
+}
+
+// ValidatorsBySigningStartHeight returns every validator with signing info
+// along with its signing start height, sorted by start height ascending.
+// Validators sharing a start height keep the store order.
+func (k Keeper) ValidatorsBySigningStartHeight(ctx sdk.Context) []types.ValidatorStart {
+	starts := []types.ValidatorStart{}
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info types.ValidatorSigningInfo) (stop bool) {
+		starts = append(starts, types.ValidatorStart{ConsAddress: address, StartHeight: info.StartHeight})
+		return false
+	})
+
+	sort.SliceStable(starts, func(i, j int) bool {
+		return starts[i].StartHeight < starts[j].StartHeight
+	})
+
+	return starts
+}
+
 // SetValidatorSigningInfo sets the validator signing info to a consensus address key
 func (k Keeper) SetValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress, info types.ValidatorSigningInfo) {
 	store := ctx.KVStore(k.storeKey)
@@ -112,6 +410,7 @@ func (k Keeper) JailUntil(ctx sdk.Context, consAddr sdk.ConsAddress, jailTime ti
 
 	signInfo.JailedUntil = jailTime
 	k.SetValidatorSigningInfo(ctx, consAddr, signInfo)
+	k.Logger(ctx).Info("set validator jailed until", "validator", consAddr.String(), "jailed_until", jailTime)
 }
 
 // Tombstone attempts to tombstone a validator. It will panic if signing info for
@@ -128,6 +427,72 @@ func (k Keeper) Tombstone(ctx sdk.Context, consAddr sdk.ConsAddress) {
 
 	signInfo.Tombstoned = true
 	k.SetValidatorSigningInfo(ctx, consAddr, signInfo)
+	k.resetUptimeStreak(ctx, consAddr)
+	k.updatePenaltySummary(ctx, consAddr, func(summary *types.PenaltySummary) { summary.Tombstones++ })
+	k.Logger(ctx).Info(
+		"tombstoned validator",
+		"validator", consAddr.String(),
+		"missed", signInfo.MissedBlocksCounter,
+	)
+}
+
+// TombstoneByOperator tombstones the validator with the given operator
+// address like Tombstone, resolving its consensus address through srstaking.
+// It errors instead of panicking if the validator or its signing info is
+// unknown or it is already tombstoned. Unlike evidence handling, which emits
+// its own slash event, a slash event with the tombstoned reason is emitted.
+func (k Keeper) TombstoneByOperator(ctx sdk.Context, valAddr sdk.ValAddress) error {
+	consAddr, err := k.ConsAddrOf(ctx, valAddr)
+	if err != nil {
+		return err
+	}
+
+	signInfo, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+	if signInfo.Tombstoned {
+		return sdkerrors.Wrap(types.ErrValidatorTombstoned, consAddr.String())
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSlash,
+			sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueTombstoned),
+		),
+	)
+	k.Tombstone(ctx, consAddr)
+	return nil
+}
+
+// ClearTombstone lifts the tombstone of a validator and ends its jail period at
+// the current block time, so it can be unjailed right away. Tombstoning is
+// meant to be permanent, this is an escape hatch for operational mistakes and
+// must only be reachable from a governance gated handler.
+func (k Keeper) ClearTombstone(ctx sdk.Context, consAddr sdk.ConsAddress) error {
+	signInfo, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+	if !signInfo.Tombstoned {
+		return sdkerrors.Wrap(types.ErrValidatorNotTombstoned, consAddr.String())
+	}
+
+	signInfo.Tombstoned = false
+	if signInfo.JailedUntil.After(ctx.BlockHeader().Time) {
+		signInfo.JailedUntil = ctx.BlockHeader().Time
+	}
+	k.SetValidatorSigningInfo(ctx, consAddr, signInfo)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTombstoneCleared,
+			sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+		),
+	)
+	k.Logger(ctx).Info("cleared validator tombstone", "validator", consAddr.String())
+	return nil
 }
 
 // IsTombstoned returns if a given validator by consensus address is tombstoned.
@@ -140,6 +505,30 @@ func (k Keeper) IsTombstoned(ctx sdk.Context, consAddr sdk.ConsAddress) bool {
 	return signInfo.Tombstoned
 }
 
+// IterateTombstoned iterates over the tombstoned validators in signing info
+// and invokes the handler with their consensus address. Iteration stops when
+// the handler returns true.
+func (k Keeper) IterateTombstoned(ctx sdk.Context, handler func(consAddr sdk.ConsAddress) (stop bool)) {
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info types.ValidatorSigningInfo) (stop bool) {
+		if !info.Tombstoned {
+			return false
+		}
+		return handler(address)
+	})
+}
+
+// GetTombstonedValidators returns the consensus addresses of every tombstoned
+// validator.
+func (k Keeper) GetTombstonedValidators(ctx sdk.Context) []sdk.ConsAddress {
+	addrs := []sdk.ConsAddress{}
+	k.IterateTombstoned(ctx, func(consAddr sdk.ConsAddress) (stop bool) {
+		addrs = append(addrs, consAddr)
+		return false
+	})
+
+	return addrs
+}
+
 // SetValidatorMissedBlockBitArray sets the bit that checks if the validator has
 // missed a block in the current window
 func (k Keeper) SetValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.ConsAddress, index int64, missed bool) {
@@ -157,3 +546,76 @@ func (k Keeper) clearValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.C
 		store.Delete(iter.Key())
 	}
 }
+
+// truncateMissedBlockBitArrays deletes the missed block bits at an index
+// beyond the given signed blocks window for every validator with signing info.
+// The missed blocks counter is then recomputed as the number of missed bits
+// left within the window, as the dropped bits would otherwise keep counting
+// against the validator without ever being overwritten.
+func (k Keeper) truncateMissedBlockBitArrays(ctx sdk.Context, window int64) {
+	var addrs []sdk.ConsAddress
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, _ types.ValidatorSigningInfo) (stop bool) {
+		addrs = append(addrs, address)
+		return false
+	})
+
+	store := ctx.KVStore(k.storeKey)
+	for _, addr := range addrs {
+		var (
+			stale  [][]byte
+			missed int64
+		)
+
+		iter := sdk.KVStorePrefixIterator(store, types.ValidatorMissedBlockBitArrayPrefixKey(addr))
+		for ; iter.Valid(); iter.Next() {
+			key := iter.Key()
+			index := int64(binary.LittleEndian.Uint64(key[len(key)-8:]))
+			if index >= window {
+				stale = append(stale, key)
+				continue
+			}
+
+			var bit gogotypes.BoolValue
+			k.cdc.MustUnmarshal(iter.Value(), &bit)
+			if bit.Value {
+				missed++
+			}
+		}
+		iter.Close()
+
+		for _, key := range stale {
+			store.Delete(key)
+		}
+
+		signInfo, _ := k.GetValidatorSigningInfo(ctx, addr)
+		signInfo.MissedBlocksCounter = missed
+		k.SetValidatorSigningInfo(ctx, addr, signInfo)
+	}
+}
+
+// ResetAllMissedBlocks clears the missed block bit array and zeroes the missed
+// blocks counter of every validator with signing info, e.g. after a coordinated
+// network restart so nobody is jailed for the outage itself. The jailed and
+// tombstoned status of validators is left untouched.
+func (k Keeper) ResetAllMissedBlocks(ctx sdk.Context) {
+	var addrs []sdk.ConsAddress
+	k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, _ types.ValidatorSigningInfo) (stop bool) {
+		addrs = append(addrs, address)
+		return false
+	})
+
+	for _, addr := range addrs {
+		signInfo, _ := k.GetValidatorSigningInfo(ctx, addr)
+		signInfo.MissedBlocksCounter = 0
+		k.clearValidatorMissedBlockBitArray(ctx, addr)
+		k.SetValidatorSigningInfo(ctx, addr, signInfo)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeMissedBlocksReset,
+			sdk.NewAttribute(types.AttributeKeyHeight, fmt.Sprintf("%d", ctx.BlockHeight())),
+			sdk.NewAttribute(types.AttributeKeyValidators, fmt.Sprintf("%d", len(addrs))),
+		),
+	)
+}
@@ -0,0 +1,427 @@
+package keeper_test
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+func TestResetAllMissedBlocks(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var addrs []sdk.ConsAddress
+	for i := 0; i < 3; i++ {
+		pk := sk.addValidator()
+		addr := sdk.ConsAddress(pk.Address())
+		addrs = append(addrs, addr)
+
+		k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 3, ctx.BlockTime(), i == 0, 2))
+		k.SetValidatorMissedBlockBitArray(ctx, addr, 0, true)
+		k.SetValidatorMissedBlockBitArray(ctx, addr, 2, true)
+	}
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	k.ResetAllMissedBlocks(ctx)
+
+	for i, addr := range addrs {
+		info, found := k.GetValidatorSigningInfo(ctx, addr)
+		require.True(t, found)
+		require.Zero(t, info.MissedBlocksCounter)
+		require.Equal(t, i == 0, info.Tombstoned)
+		require.Empty(t, k.GetValidatorMissedBlocks(ctx, addr))
+	}
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeMissedBlocksReset, events[0].Type)
+}
+
+func TestHasEverSigned(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	_, err := k.HasEverSigned(ctx, sdk.ConsAddress("unknown"))
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	signed, err := k.HasEverSigned(ctx, consAddr)
+	require.NoError(t, err)
+	require.False(t, signed)
+
+	ctx = missBlocks(ctx, k, pk, 2)
+	signed, err = k.HasEverSigned(ctx, consAddr)
+	require.NoError(t, err)
+	require.False(t, signed)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	signed, err = k.HasEverSigned(ctx, consAddr)
+	require.NoError(t, err)
+	require.True(t, signed)
+}
+
+func TestHasEverSignedAfterMissedBlocksReset(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	ctx = missBlocks(ctx, k, pk, 3)
+	k.ResetAllMissedBlocks(ctx)
+
+	signed, err := k.HasEverSigned(ctx, consAddr)
+	require.NoError(t, err)
+	require.False(t, signed)
+}
+
+func TestHasEverSignedAfterWindowTruncation(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	ctx = missBlocks(ctx, k, pk, 8)
+	params := k.GetParams(ctx)
+	params.SignedBlocksWindow = 5
+	k.SetParams(ctx, params)
+
+	info, _ := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.Less(t, info.MissedBlocksCounter, info.IndexOffset)
+	signed, err := k.HasEverSigned(ctx, consAddr)
+	require.NoError(t, err)
+	require.False(t, signed)
+}
+
+func TestHasEverSignedWhileJailingPaused(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := setupLiveness(t, ctx, k, sk)
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	// missing past the window wraps the bit array, capping the counter
+	k.SetDowntimeJailingPaused(ctx, true)
+	ctx = missBlocks(ctx, k, pk, 15)
+
+	info, _ := k.GetValidatorSigningInfo(ctx, consAddr)
+	require.Less(t, info.MissedBlocksCounter, info.IndexOffset)
+	signed, err := k.HasEverSigned(ctx, consAddr)
+	require.NoError(t, err)
+	require.False(t, signed)
+}
+
+func TestGetTombstonedValidators(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var tombstoned []sdk.ConsAddress
+	for i := 0; i < 3; i++ {
+		pk := sk.addValidator()
+		addr := sdk.ConsAddress(pk.Address())
+		k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 0, ctx.BlockTime(), false, 0))
+		if i != 1 {
+			k.Tombstone(ctx, addr)
+			tombstoned = append(tombstoned, addr)
+		}
+	}
+
+	require.ElementsMatch(t, tombstoned, k.GetTombstonedValidators(ctx))
+
+	var visited int
+	k.IterateTombstoned(ctx, func(sdk.ConsAddress) bool {
+		visited++
+		return true
+	})
+	require.Equal(t, 1, visited)
+}
+
+func TestTombstoneByOperator(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := sk.addValidator()
+	valAddr := sdk.ValAddress(pk.Address())
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	unknown := sdk.ValAddress(ed25519.GenPrivKey().PubKey().Address())
+	require.ErrorIs(t, k.TombstoneByOperator(ctx, unknown), types.ErrBadValidatorAddr)
+	require.ErrorIs(t, k.TombstoneByOperator(ctx, valAddr), types.ErrNoSigningInfoFound)
+
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 0, ctx.BlockTime(), false, 0))
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.TombstoneByOperator(ctx, valAddr))
+	require.True(t, k.IsTombstoned(ctx, consAddr))
+	require.Equal(t, sdk.Events{sdk.NewEvent(
+		types.EventTypeSlash,
+		sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyReason, types.AttributeValueTombstoned),
+	)}, ctx.EventManager().Events())
+
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.ErrorIs(t, k.TombstoneByOperator(ctx, valAddr), types.ErrValidatorTombstoned)
+	require.Empty(t, ctx.EventManager().Events())
+}
+
+func TestClearTombstone(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	pk := sk.addValidator()
+	addr := sdk.ConsAddress(pk.Address())
+
+	require.ErrorIs(t, k.ClearTombstone(ctx, addr), types.ErrNoSigningInfoFound)
+
+	k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 0, ctx.BlockTime(), false, 0))
+	require.ErrorIs(t, k.ClearTombstone(ctx, addr), types.ErrValidatorNotTombstoned)
+
+	// jailed forever, as done for double signing
+	sk.Jail(ctx, addr)
+	k.JailUntil(ctx, addr, time.Unix(253402300799, 0))
+	k.Tombstone(ctx, addr)
+
+	require.NoError(t, k.ClearTombstone(ctx, addr))
+	require.False(t, k.IsTombstoned(ctx, addr))
+	require.Equal(t, types.EventTypeTombstoneCleared, ctx.EventManager().Events()[0].Type)
+
+	height, err := k.UnjailEligibleHeight(ctx, addr)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight(), height)
+}
+
+func TestGetSigningStartHeight(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	consAddr := sdk.ConsAddress("validator")
+
+	_, err := k.GetSigningStartHeight(ctx, consAddr)
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 42, 0, ctx.BlockTime(), false, 0))
+	height, err := k.GetSigningStartHeight(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), height)
+}
+
+func TestGetIndexOffset(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	_, err := k.GetIndexOffset(ctx, consAddr)
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	require.NoError(t, k.AddPubkey(ctx, pk))
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 0, 4, ctx.BlockTime(), false, 0))
+	offset, err := k.GetIndexOffset(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), offset)
+
+	for i := 0; i < 3; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	}
+	offset, err = k.GetIndexOffset(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), offset)
+}
+
+func TestLastSignedHeight(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	require.NoError(t, k.AddPubkey(ctx, pk))
+	k.AfterValidatorBonded(ctx, consAddr, sdk.ValAddress(pk.Address()))
+
+	_, err := k.LastSignedHeight(ctx, consAddr)
+	require.Error(t, err)
+
+	for _, signed := range []bool{true, true, false} {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, pk.Address(), 1, signed)
+	}
+	height, err := k.LastSignedHeight(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight()-1, height)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	k.HandleValidatorSignature(ctx, pk.Address(), 1, true)
+	height, err = k.LastSignedHeight(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight(), height)
+}
+
+func TestDetectDesync(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var addrs []sdk.ConsAddress
+	for i := 0; i < 5; i++ {
+		addrs = append(addrs, sdk.ConsAddress(sk.addValidator().Address()))
+	}
+	k.SetValidatorSigningInfo(ctx, addrs[4], types.NewValidatorSigningInfo(addrs[4], 1, 0, time.Unix(0, 0), false, 0))
+
+	// missing signing info, signing info under another address, a tombstone
+	// left unjailed, and a jailed validator that isn't tracked anymore
+	k.SetValidatorSigningInfo(ctx, addrs[1], types.NewValidatorSigningInfo(addrs[0], 1, 0, time.Unix(0, 0), false, 0))
+	k.SetValidatorSigningInfo(ctx, addrs[2], types.NewValidatorSigningInfo(addrs[2], 1, 0, time.Unix(0, 0), true, 0))
+	sk.validators[3].jailed = true
+
+	require.Equal(t, []types.DesyncReport{
+		{ConsAddress: addrs[0], Reason: types.DesyncMissingSigningInfo},
+		{ConsAddress: addrs[1], Reason: types.DesyncAddressMismatch},
+		{ConsAddress: addrs[2], Reason: types.DesyncTombstonedUnjailed},
+	}, k.DetectDesync(ctx, sk))
+}
+
+func TestValidatorsBySigningStartHeight(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	heights := []int64{30, 10, 20}
+	addrs := make([]sdk.ConsAddress, len(heights))
+	for i, height := range heights {
+		pk := sk.addValidator()
+		addrs[i] = sdk.ConsAddress(pk.Address())
+		k.SetValidatorSigningInfo(ctx, addrs[i], types.NewValidatorSigningInfo(addrs[i], height, 0, ctx.BlockTime(), false, 0))
+	}
+
+	require.Equal(t, []types.ValidatorStart{
+		{ConsAddress: addrs[1], StartHeight: 10},
+		{ConsAddress: addrs[2], StartHeight: 20},
+		{ConsAddress: addrs[0], StartHeight: 30},
+	}, k.ValidatorsBySigningStartHeight(ctx))
+}
+
+func TestValidatorsByMissedBlocks(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	missed := []int64{3, 9, 0, 5}
+	addrs := make([]sdk.ConsAddress, len(missed))
+	for i, count := range missed {
+		addrs[i] = sdk.ConsAddress(sk.addValidator().Address())
+		k.SetValidatorSigningInfo(ctx, addrs[i], types.NewValidatorSigningInfo(addrs[i], 1, 10, ctx.BlockTime(), false, count))
+	}
+	k.Tombstone(ctx, addrs[3])
+
+	require.Equal(t, []types.ValidatorMiss{
+		{ConsAddress: addrs[1], MissedBlocks: 9},
+		{ConsAddress: addrs[3], MissedBlocks: 5, Tombstoned: true},
+		{ConsAddress: addrs[0], MissedBlocks: 3},
+	}, k.ValidatorsByMissedBlocks(ctx, 3))
+	require.Len(t, k.ValidatorsByMissedBlocks(ctx, 0), 4)
+	require.Len(t, k.ValidatorsByMissedBlocks(ctx, 10), 4)
+}
+
+func TestSnapshotSigningInfos(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var addrs []sdk.ConsAddress
+	for i := 0; i < 2; i++ {
+		pk := sk.addValidator()
+		require.NoError(t, k.AddPubkey(ctx, pk))
+		k.AfterValidatorBonded(ctx, sdk.ConsAddress(pk.Address()), sdk.ValAddress(pk.Address()))
+		addrs = append(addrs, sdk.ConsAddress(pk.Address()))
+	}
+
+	before := k.SnapshotSigningInfos(ctx)
+	require.Equal(t, ctx.BlockHeight(), before.Height)
+	require.Len(t, before.Infos, 2)
+
+	// the first validator misses 3 blocks, the second signs them
+	for i := 0; i < 3; i++ {
+		ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+		k.HandleValidatorSignature(ctx, addrs[0].Bytes(), 1, false)
+		k.HandleValidatorSignature(ctx, addrs[1].Bytes(), 1, true)
+	}
+	late := sdk.ConsAddress(sk.addValidator().Address())
+	k.SetValidatorSigningInfo(ctx, late, types.NewValidatorSigningInfo(late, ctx.BlockHeight(), 0, ctx.BlockTime(), false, 1))
+
+	after := k.SnapshotSigningInfos(ctx)
+	require.Equal(t, before.Height+3, after.Height)
+
+	expected := []types.SigningInfoDelta{
+		{Address: addrs[0].String(), MissedBlocksDelta: 3},
+		{Address: addrs[1].String(), MissedBlocksDelta: 0},
+		{Address: late.String(), MissedBlocksDelta: 1},
+	}
+	sort.Slice(expected, func(i, j int) bool { return expected[i].Address < expected[j].Address })
+	require.Equal(t, expected, types.CompareSigningSnapshots(before, after))
+}
+
+func TestAnyValidatorJailed(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	require.False(t, k.AnyValidatorJailed(ctx))
+
+	var addrs []sdk.ConsAddress
+	for i := 0; i < 2; i++ {
+		pk := sk.addValidator()
+		addr := sdk.ConsAddress(pk.Address())
+		addrs = append(addrs, addr)
+		k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 0, ctx.BlockTime(), false, 0))
+	}
+	require.False(t, k.AnyValidatorJailed(ctx))
+
+	sk.Jail(ctx, addrs[1])
+	require.True(t, k.AnyValidatorJailed(ctx))
+}
+
+func TestJailedFraction(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	require.True(t, k.JailedFraction(ctx, sk).IsZero())
+
+	for i := 0; i < 4; i++ {
+		addr := sdk.ConsAddress(sk.addValidator().Address())
+		if i == 0 {
+			sk.Jail(ctx, addr)
+		}
+	}
+
+	require.Equal(t, sdk.NewDecWithPrec(25, 2), k.JailedFraction(ctx, sk))
+}
+
+func TestNetworkUptime(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	require.True(t, k.NetworkUptime(ctx, sk).IsZero())
+
+	// window of 100 blocks: 100 + 90 + 60 signed out of 300, the jailed
+	// validator and the one without signing info are left out
+	for i, missed := range []int64{0, 10, 40, 100} {
+		addr := sdk.ConsAddress(sk.addValidator().Address())
+		k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 100, ctx.BlockTime(), false, missed))
+		if i == 3 {
+			sk.validators[i].jailed = true
+		}
+	}
+	sk.addValidator()
+
+	require.Equal(t, types.DefaultSignedBlocksWindow, k.SignedBlocksWindow(ctx))
+	require.Equal(t, sdk.NewDecWithPrec(25, 1).QuoInt64(3), k.NetworkUptime(ctx, sk))
+}
+
+func TestValidatorsWithoutSigningInfo(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var missing []sdk.ConsAddress
+	for i := 0; i < 4; i++ {
+		addr := sdk.ConsAddress(sk.addValidator().Address())
+		if i%2 == 0 {
+			k.SetValidatorSigningInfo(ctx, addr, types.NewValidatorSigningInfo(addr, 1, 0, ctx.BlockTime(), false, 0))
+		} else {
+			missing = append(missing, addr)
+		}
+	}
+
+	require.Equal(t, missing, k.ValidatorsWithoutSigningInfo(ctx, sk))
+}
+
+func TestGetAllValidatorSigningInfos(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	var expected []types.SigningInfo
+	for i := int64(0); i < 3; i++ {
+		pk := sk.addValidator()
+		addr := sdk.ConsAddress(pk.Address())
+		info := types.NewValidatorSigningInfo(addr, i+1, i, ctx.BlockTime(), false, 0)
+		k.SetValidatorSigningInfo(ctx, addr, info)
+		expected = append(expected, types.SigningInfo{Address: addr.String(), ValidatorSigningInfo: info})
+	}
+	sort.Slice(expected, func(i, j int) bool { return expected[i].Address < expected[j].Address })
+
+	require.Equal(t, expected, k.GetAllValidatorSigningInfos(ctx))
+}
@@ -0,0 +1,225 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"gea-poa/x/slashing/types"
+)
+
+// SlashWithInfractionReason slashes a validator through the staking module
+// for the given infraction and persists a SlashRecord of it. The distribution
+// height is the height of the stake distribution the slash is applied to.
+// A non-positive power or fraction is rejected before reaching the staking
+// module, as is any slash when it is not a BondedStakingKeeper.
+func (k Keeper) SlashWithInfractionReason(ctx sdk.Context, consAddr sdk.ConsAddress, fraction sdk.Dec, power, distributionHeight int64, reason types.Infraction) error {
+	if power <= 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidSlashPower, "validator %s: power %d", consAddr, power)
+	}
+	if fraction.IsNil() || !fraction.IsPositive() {
+		return sdkerrors.Wrapf(types.ErrInvalidSlashFraction, "validator %s: fraction %s", consAddr, fraction)
+	}
+	sk, ok := k.bondedStaking()
+	if !ok {
+		return sdkerrors.Wrapf(types.ErrNoBondedTokens, "validator %s", consAddr)
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeSlash,
+			sdk.NewAttribute(types.AttributeKeyAddress, consAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyPower, fmt.Sprintf("%d", power)),
+			sdk.NewAttribute(types.AttributeKeyReason, reason.EventReason()),
+		),
+	)
+
+	burned := sk.Slash(ctx, consAddr, distributionHeight, power, fraction)
+	k.addSlashedThisBlock(ctx, sdk.NewCoin(sk.BondDenom(ctx), burned))
+	k.SetSlashRecord(ctx, types.SlashRecord{
+		Address:            consAddr.String(),
+		Height:             ctx.BlockHeight(),
+		DistributionHeight: distributionHeight,
+		Reason:             reason,
+		Fraction:           fraction,
+		Power:              power,
+		Time:               ctx.BlockHeader().Time,
+	})
+
+	k.Logger(ctx).Info(
+		"slashed validator",
+		"validator", consAddr.String(),
+		"reason", reason.String(),
+		"fraction", fraction.String(),
+		"power", power,
+		"burned", burned.String(),
+	)
+	return nil
+}
+
+// EstimateSlashTokens returns the tokens a slash of the given fraction at the
+// given power would burn, using the default power reduction. The actual burn
+// may be lower when the validator holds fewer tokens than its power implies.
+// The same power and fraction checks as SlashWithInfractionReason apply.
+func (k Keeper) EstimateSlashTokens(ctx sdk.Context, consAddr sdk.ConsAddress, fraction sdk.Dec, power int64) (sdk.Int, error) {
+	if k.Sk.ValidatorByConsAddr(ctx, consAddr) == nil {
+		return sdk.Int{}, sdkerrors.Wrap(types.ErrNoValidatorForAddress, consAddr.String())
+	}
+	if power <= 0 {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidSlashPower, "validator %s: power %d", consAddr, power)
+	}
+	if fraction.IsNil() || !fraction.IsPositive() {
+		return sdk.Int{}, sdkerrors.Wrapf(types.ErrInvalidSlashFraction, "validator %s: fraction %s", consAddr, fraction)
+	}
+
+	tokens := sdk.TokensFromConsensusPower(power, sdk.DefaultPowerReduction)
+	return fraction.MulInt(tokens).TruncateInt(), nil
+}
+
+// addSlashedThisBlock adds a slashed amount to the tokens slashed in the
+// current block.
+func (k Keeper) addSlashedThisBlock(ctx sdk.Context, amount sdk.Coin) {
+	store := ctx.TransientStore(k.tkey)
+	key := types.SlashedThisBlockKey(amount.Denom)
+
+	total := sdk.IntProto{Int: sdk.ZeroInt()}
+	if bz := store.Get(key); bz != nil {
+		k.cdc.MustUnmarshal(bz, &total)
+	}
+
+	total.Int = total.Int.Add(amount.Amount)
+	store.Set(key, k.cdc.MustMarshal(&total))
+}
+
+// SlashedThisBlock returns the tokens burned by the staking module for the
+// slashes of the current block. They are kept in the transient store, which
+// is cleared at the end of every block.
+func (k Keeper) SlashedThisBlock(ctx sdk.Context) sdk.Coins {
+	coins := sdk.NewCoins()
+	store := ctx.TransientStore(k.tkey)
+	iter := sdk.KVStorePrefixIterator(store, types.SlashedThisBlockKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var amount sdk.IntProto
+		k.cdc.MustUnmarshal(iter.Value(), &amount)
+		denom := string(iter.Key()[len(types.SlashedThisBlockKeyPrefix):])
+		coins = coins.Add(sdk.NewCoin(denom, amount.Int))
+	}
+
+	return coins
+}
+
+// EffectiveSlashFraction returns the fraction a validator is slashed by for
+// the given infraction: the slash fraction param of the infraction, zero for
+// the downtime of slash exempt validators, capped at one. Unspecified
+// infractions are never slashed.
+func (k Keeper) EffectiveSlashFraction(ctx sdk.Context, consAddr sdk.ConsAddress, reason types.Infraction) sdk.Dec {
+	var fraction sdk.Dec
+	switch reason {
+	case types.InfractionDoubleSign:
+		fraction = k.SlashFractionDoubleSign(ctx)
+	case types.InfractionDowntime:
+		if k.IsSlashExempt(ctx, consAddr) {
+			return sdk.ZeroDec()
+		}
+		fraction = k.SlashFractionDowntime(ctx)
+	default:
+		return sdk.ZeroDec()
+	}
+
+	return sdk.MinDec(fraction, sdk.OneDec())
+}
+
+// SlashAtInfractionPower slashes a validator for an infraction committed at
+// infractionHeight, computing the burn against infractionPower, the power the
+// validator had at that height. Slashing at the current power instead would
+// under-penalize a validator that reduced its stake since the infraction.
+func (k Keeper) SlashAtInfractionPower(ctx sdk.Context, consAddr sdk.ConsAddress, fraction sdk.Dec, infractionHeight, infractionPower int64, reason types.Infraction) error {
+	// the stake distribution that signed at infractionHeight was set
+	// ValidatorUpdateDelay blocks earlier
+	distributionHeight := infractionHeight - sdk.ValidatorUpdateDelay
+	return k.SlashWithInfractionReason(ctx, consAddr, fraction, infractionPower, distributionHeight, reason)
+}
+
+// SetSlashRecord persists a slash record keyed by the validator, the height it
+// was slashed at and the number of slashes already recorded for it at that
+// height, and indexes it by the time it was slashed at for PruneSlashRecords.
+func (k Keeper) SetSlashRecord(ctx sdk.Context, record types.SlashRecord) {
+	consAddr, err := sdk.ConsAddressFromBech32(record.Address)
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	key := types.SlashRecordKey(consAddr, record.Height, k.slashRecordCount(ctx, consAddr, record.Height))
+	store.Set(key, k.cdc.MustMarshal(&record))
+	store.Set(types.SlashRecordTimeKey(record.Time, key), key)
+}
+
+// slashRecordCount returns the number of slash records of a validator at the
+// given height.
+func (k Keeper) slashRecordCount(ctx sdk.Context, consAddr sdk.ConsAddress, height int64) uint64 {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.SlashRecordHeightPrefixKey(consAddr, height))
+	defer iter.Close()
+
+	var count uint64
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+
+	return count
+}
+
+// GetSlashRecords returns the slash records of a validator ordered by height.
+func (k Keeper) GetSlashRecords(ctx sdk.Context, consAddr sdk.ConsAddress) []types.SlashRecord {
+	records := []types.SlashRecord{}
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.SlashRecordPrefixKey(consAddr))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var record types.SlashRecord
+		k.cdc.MustUnmarshal(iter.Value(), &record)
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// TotalSlashedPower returns the consensus power a validator has been slashed
+// by, i.e. the sum of fraction * power over its slash records. It is not a
+// token amount and only covers records not yet pruned by PruneSlashRecords.
+func (k Keeper) TotalSlashedPower(ctx sdk.Context, consAddr sdk.ConsAddress) sdk.Dec {
+	total := sdk.ZeroDec()
+	for _, record := range k.GetSlashRecords(ctx, consAddr) {
+		total = total.Add(record.Fraction.MulInt64(record.Power))
+	}
+
+	return total
+}
+
+// PruneSlashRecords deletes every slash record older than the unbonding
+// period. Only the expired part of the time index is iterated. Slash records
+// are only ever set through a BondedStakingKeeper, see
+// SlashWithInfractionReason.
+func (k Keeper) PruneSlashRecords(ctx sdk.Context) {
+	sk, ok := k.bondedStaking()
+	if !ok {
+		return
+	}
+	cutoff := ctx.BlockHeader().Time.Add(-sk.UnbondingTime(ctx))
+
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.SlashRecordTimeKeyPrefix, types.SlashRecordTimePrefixKey(cutoff))
+	defer iter.Close()
+
+	var expired [][]byte
+	for ; iter.Valid(); iter.Next() {
+		expired = append(expired, iter.Key(), iter.Value())
+	}
+
+	for _, key := range expired {
+		store.Delete(key)
+	}
+}
@@ -0,0 +1,171 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+func TestSlashRecords(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	sk.unbondingTime = time.Hour
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	fraction := sdk.NewDecWithPrec(5, 2)
+
+	ctx = ctx.WithBlockHeight(10)
+	require.NoError(t, k.SlashWithInfractionReason(ctx, consAddr, fraction, 100, 8, types.InfractionDoubleSign))
+	ctx = ctx.WithBlockHeight(20).WithBlockTime(ctx.BlockTime().Add(time.Minute))
+	require.NoError(t, k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(1, 2), 90, 18, types.InfractionDowntime))
+
+	require.Len(t, sk.slashes, 2)
+	require.Equal(t, int64(8), sk.slashes[0].infractionHeight)
+
+	records := k.GetSlashRecords(ctx, consAddr)
+	require.Len(t, records, 2)
+	require.Equal(t, int64(10), records[0].Height)
+	require.Equal(t, types.InfractionDoubleSign, records[0].Reason)
+	require.Equal(t, fraction, records[0].Fraction)
+	require.Equal(t, int64(100), records[0].Power)
+	require.Equal(t, int64(20), records[1].Height)
+	require.Equal(t, types.InfractionDowntime, records[1].Reason)
+
+	// 0.05 * 100 + 0.01 * 90
+	require.Equal(t, sdk.NewDecWithPrec(59, 1), k.TotalSlashedPower(ctx, consAddr))
+
+	// a second slash in the same block is recorded separately
+	require.NoError(t, k.SlashWithInfractionReason(ctx, consAddr, fraction, 90, 18, types.InfractionDoubleSign))
+	records = k.GetSlashRecords(ctx, consAddr)
+	require.Len(t, records, 3)
+	require.Equal(t, types.InfractionDowntime, records[1].Reason)
+	require.Equal(t, types.InfractionDoubleSign, records[2].Reason)
+
+	// the first record expires first
+	ctx = ctx.WithBlockTime(records[0].Time.Add(time.Hour + time.Second))
+	k.PruneSlashRecords(ctx)
+	records = k.GetSlashRecords(ctx, consAddr)
+	require.Len(t, records, 2)
+	require.Equal(t, int64(20), records[0].Height)
+
+	ctx = ctx.WithBlockTime(records[0].Time.Add(time.Hour + time.Second))
+	k.PruneSlashRecords(ctx)
+	require.Empty(t, k.GetSlashRecords(ctx, consAddr))
+}
+
+func TestSlashWithInfractionReasonValidation(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	err := k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(5, 2), 0, 1, types.InfractionDoubleSign)
+	require.ErrorIs(t, err, types.ErrInvalidSlashPower)
+
+	err = k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(-5, 2), 100, 1, types.InfractionDoubleSign)
+	require.ErrorIs(t, err, types.ErrInvalidSlashFraction)
+
+	require.Empty(t, sk.slashes)
+	require.Empty(t, k.GetSlashRecords(ctx, consAddr))
+}
+
+func TestSlashWithInfractionReasonNoBondedTokens(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	consAddr := sdk.ConsAddress(sk.addValidator().Address())
+
+	// a staking keeper without bonded tokens, as srstaking
+	k.Sk = struct{ types.StakingKeeper }{sk}
+	err := k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(5, 2), 100, 1, types.InfractionDoubleSign)
+	require.ErrorIs(t, err, types.ErrNoBondedTokens)
+
+	require.Empty(t, sk.slashes)
+	require.Empty(t, k.GetSlashRecords(ctx, consAddr))
+}
+
+func TestTotalSlashedPowerNoRecords(t *testing.T) {
+	ctx, k, _ := createTestInput(t)
+	require.True(t, k.TotalSlashedPower(ctx, sdk.ConsAddress("validator")).IsZero())
+}
+
+func TestSlashAtInfractionPower(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+	fraction := sdk.NewDecWithPrec(5, 2)
+
+	ctx = ctx.WithBlockHeight(50)
+	require.NoError(t, k.SlashAtInfractionPower(ctx, consAddr, fraction, 40, 120, types.InfractionDoubleSign))
+
+	require.Equal(t, []slashCall{{consAddr, 40 - sdk.ValidatorUpdateDelay, 120, fraction}}, sk.slashes)
+	records := k.GetSlashRecords(ctx, consAddr)
+	require.Len(t, records, 1)
+	require.Equal(t, int64(120), records[0].Power)
+
+	err := k.SlashAtInfractionPower(ctx, consAddr, fraction, 40, 0, types.InfractionDoubleSign)
+	require.ErrorIs(t, err, types.ErrInvalidSlashPower)
+}
+
+func TestEffectiveSlashFraction(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	params := k.GetParams(ctx)
+	params.SlashFractionDoubleSign = sdk.NewDecWithPrec(5, 2)
+	params.SlashFractionDowntime = sdk.NewDecWithPrec(1, 2)
+	k.SetParams(ctx, params)
+
+	normal := sdk.ConsAddress(sk.addValidator().Address())
+	exempt := sdk.ConsAddress(sk.addValidator().Address())
+	k.SetSlashExempt(ctx, exempt)
+
+	require.Equal(t, sdk.NewDecWithPrec(1, 2), k.EffectiveSlashFraction(ctx, normal, types.InfractionDowntime))
+	require.True(t, k.EffectiveSlashFraction(ctx, exempt, types.InfractionDowntime).IsZero())
+
+	// exemptions only cover downtime
+	require.Equal(t, sdk.NewDecWithPrec(5, 2), k.EffectiveSlashFraction(ctx, normal, types.InfractionDoubleSign))
+	require.Equal(t, sdk.NewDecWithPrec(5, 2), k.EffectiveSlashFraction(ctx, exempt, types.InfractionDoubleSign))
+
+	require.True(t, k.EffectiveSlashFraction(ctx, normal, types.InfractionUnspecified).IsZero())
+}
+
+func TestEstimateSlashTokens(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	consAddr := sdk.ConsAddress(sk.addValidator().Address())
+
+	// 5% of 100 power
+	tokens, err := k.EstimateSlashTokens(ctx, consAddr, sdk.NewDecWithPrec(5, 2), 100)
+	require.NoError(t, err)
+	require.Equal(t, sdk.TokensFromConsensusPower(5, sdk.DefaultPowerReduction), tokens)
+
+	_, err = k.EstimateSlashTokens(ctx, consAddr, sdk.NewDecWithPrec(5, 2), 0)
+	require.ErrorIs(t, err, types.ErrInvalidSlashPower)
+	_, err = k.EstimateSlashTokens(ctx, consAddr, sdk.ZeroDec(), 100)
+	require.ErrorIs(t, err, types.ErrInvalidSlashFraction)
+	_, err = k.EstimateSlashTokens(ctx, sdk.ConsAddress("unknown"), sdk.NewDecWithPrec(5, 2), 100)
+	require.ErrorIs(t, err, types.ErrNoValidatorForAddress)
+
+	// estimating doesn't slash
+	require.Empty(t, sk.slashes)
+}
+
+func TestSlashedThisBlock(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	consAddr := sdk.ConsAddress(sk.addValidator().Address())
+	require.True(t, k.SlashedThisBlock(ctx).IsZero())
+
+	require.NoError(t, k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(5, 2), 100, 1, types.InfractionDoubleSign))
+	require.NoError(t, k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(1, 2), 50, 1, types.InfractionDowntime))
+
+	// 5% of 100 power plus 1% of 50 power
+	expected := sdk.TokensFromConsensusPower(5, sdk.DefaultPowerReduction).
+		Add(sdk.TokensFromConsensusPower(1, sdk.DefaultPowerReduction).QuoRaw(2))
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, expected)), k.SlashedThisBlock(ctx))
+
+	// only the tokens actually burned are counted
+	sk.byConsAddr(consAddr).tokens = sdk.TokensFromConsensusPower(1, sdk.DefaultPowerReduction)
+	require.NoError(t, k.SlashWithInfractionReason(ctx, consAddr, sdk.NewDecWithPrec(5, 2), 100, 1, types.InfractionDoubleSign))
+	expected = expected.Add(sdk.TokensFromConsensusPower(1, sdk.DefaultPowerReduction))
+	require.Equal(t, sdk.NewCoins(sdk.NewCoin(sdk.DefaultBondDenom, expected)), k.SlashedThisBlock(ctx))
+}
@@ -0,0 +1,110 @@
+package keeper
+
+import (
+	"math"
+	"time"
+
+	gogotypes "github.com/gogo/protobuf/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+// DowntimeStrikes returns how many times a validator has been jailed for
+// downtime since its strikes were last cleared.
+func (k Keeper) DowntimeStrikes(ctx sdk.Context, consAddr sdk.ConsAddress) int64 {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.ValidatorDowntimeStrikesKey(consAddr))
+	if bz == nil {
+		return 0
+	}
+
+	var strikes gogotypes.Int64Value
+	k.cdc.MustUnmarshal(bz, &strikes)
+	return strikes.Value
+}
+
+// SetDowntimeStrikes sets the downtime strike counter of a validator.
+func (k Keeper) SetDowntimeStrikes(ctx sdk.Context, consAddr sdk.ConsAddress, strikes int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&gogotypes.Int64Value{Value: strikes})
+	store.Set(types.ValidatorDowntimeStrikesKey(consAddr), bz)
+}
+
+// ClearDowntimeStrikes resets the downtime strike counter of a validator. It is
+// meant to be triggered by governance once a repeat offender is forgiven.
+func (k Keeper) ClearDowntimeStrikes(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.ValidatorDowntimeStrikesKey(consAddr))
+}
+
+// EscalatedDowntimeJailDuration returns the downtime jail duration for a
+// validator with the given amount of previous strikes:
+// DowntimeJailDuration * 2^strikes, with the exponent capped at
+// types.MaxDowntimeStrikeEscalation.
+func (k Keeper) EscalatedDowntimeJailDuration(ctx sdk.Context, strikes int64) time.Duration {
+	return escalateJailDuration(k.DowntimeJailDuration(ctx), strikes)
+}
+
+// SetDowntimeJailDurationOverride sets a validator specific downtime jail
+// duration, used instead of the DowntimeJailDuration param.
+func (k Keeper) SetDowntimeJailDurationOverride(ctx sdk.Context, consAddr sdk.ConsAddress, duration time.Duration) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(gogotypes.DurationProto(duration))
+	store.Set(types.DowntimeJailDurationKey(consAddr), bz)
+}
+
+// RemoveDowntimeJailDurationOverride removes the downtime jail duration
+// override of a validator.
+func (k Keeper) RemoveDowntimeJailDurationOverride(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.DowntimeJailDurationKey(consAddr))
+}
+
+// GetDowntimeJailDurationOverride returns the downtime jail duration override
+// of a validator, if any.
+func (k Keeper) GetDowntimeJailDurationOverride(ctx sdk.Context, consAddr sdk.ConsAddress) (time.Duration, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.DowntimeJailDurationKey(consAddr))
+	if bz == nil {
+		return 0, false
+	}
+
+	var duration gogotypes.Duration
+	k.cdc.MustUnmarshal(bz, &duration)
+	d, err := gogotypes.DurationFromProto(&duration)
+	if err != nil {
+		panic(err)
+	}
+	return d, true
+}
+
+// CurrentDowntimeJailDuration returns the jail duration a validator would
+// receive if it were jailed for downtime right now: its override, or the
+// DowntimeJailDuration param, escalated by its current strike count.
+func (k Keeper) CurrentDowntimeJailDuration(ctx sdk.Context, consAddr sdk.ConsAddress) time.Duration {
+	base, ok := k.GetDowntimeJailDurationOverride(ctx, consAddr)
+	if !ok {
+		base = k.DowntimeJailDuration(ctx)
+	}
+
+	return escalateJailDuration(base, k.DowntimeStrikes(ctx, consAddr))
+}
+
+// escalateJailDuration returns base * 2^strikes, with the exponent capped at
+// types.MaxDowntimeStrikeEscalation and the result saturating at the longest
+// representable duration.
+func escalateJailDuration(base time.Duration, strikes int64) time.Duration {
+	if strikes < 0 {
+		strikes = 0
+	}
+	if strikes > types.MaxDowntimeStrikeEscalation {
+		strikes = types.MaxDowntimeStrikeEscalation
+	}
+
+	if base > time.Duration(math.MaxInt64>>uint64(strikes)) {
+		return time.Duration(math.MaxInt64)
+	}
+	return base << uint64(strikes)
+}
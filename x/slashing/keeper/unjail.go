@@ -1,12 +1,34 @@
 package keeper
 
 import (
+	"time"
+
+	gogotypes "github.com/gogo/protobuf/types"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"gea-poa/x/slashing/types"
 )
 
 // Unjail calls the srstaking Unjail function to unjail a validator if the
-// jailed period has concluded
+// jailed period has concluded. A validator can't be unjailed again within
+// UnjailCooldownBlocks of its last unjail.
 func (k Keeper) Unjail(ctx sdk.Context, validatorAddr sdk.ValAddress) error {
+	consAddr, err := k.ConsAddrOf(ctx, validatorAddr)
+	if err != nil {
+		return err
+	}
+
+	// cannot be unjailed if not jailed
+	if !k.Sk.GETValidator(ctx, validatorAddr).IsJailed() {
+		return sdkerrors.Wrap(types.ErrValidatorNotJailed, consAddr.String())
+	}
+
+	if err := k.checkUnjailCooldown(ctx, consAddr); err != nil {
+		return err
+	}
+
 	//validator := k.sk.Validator(ctx, validatorAddr)
 	//if validator == nil {
 	//	return types.ErrNoValidatorForAddress
@@ -57,5 +79,117 @@ func (k Keeper) Unjail(ctx sdk.Context, validatorAddr sdk.ValAddress) error {
 	//}
 	//
 	//k.sk.Unjail(ctx, consAddr)
+
+	return nil
+}
+
+// UnjailAfterJailPeriod unjails a validator whose jailed period has concluded
+// through srstaking, unless it was already unjailed within
+// UnjailCooldownBlocks, and records the height of the unjail.
+func (k Keeper) UnjailAfterJailPeriod(ctx sdk.Context, consAddr sdk.ConsAddress) error {
+	if err := k.checkUnjailCooldown(ctx, consAddr); err != nil {
+		return err
+	}
+
+	k.Sk.Unjail(ctx, consAddr)
+	k.setLastUnjailHeight(ctx, consAddr, ctx.BlockHeight())
+	return nil
+}
+
+// checkUnjailCooldown returns an error if the cooldown of the last unjail of a
+// validator is not over yet.
+func (k Keeper) checkUnjailCooldown(ctx sdk.Context, consAddr sdk.ConsAddress) error {
+	if last, found := k.getLastUnjailHeight(ctx, consAddr); found {
+		if next := last + k.UnjailCooldownBlocks(ctx); ctx.BlockHeight() < next {
+			return sdkerrors.Wrapf(types.ErrUnjailCooldown, "%s can be unjailed from height %d", consAddr, next)
+		}
+	}
+
 	return nil
 }
+
+// TimeUntilRemoval returns how long until a jailed validator becomes eligible
+// for removal, i.e. until it has stayed jailed for MaxJailDuration since it
+// was last jailed, and whether removal is enabled at all. The countdown is 0
+// once the validator is eligible, and always 0 if removal is disabled. It
+// starts at the jailed until time for validators jailed before jail times were
+// recorded.
+func (k Keeper) TimeUntilRemoval(ctx sdk.Context, consAddr sdk.ConsAddress) (time.Duration, bool, error) {
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return 0, false, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	validator := k.Sk.ValidatorByConsAddr(ctx, consAddr)
+	if validator == nil {
+		return 0, false, sdkerrors.Wrap(types.ErrNoValidatorForAddress, consAddr.String())
+	}
+	if !validator.IsJailed() {
+		return 0, false, sdkerrors.Wrap(types.ErrValidatorNotJailed, consAddr.String())
+	}
+
+	maxJail := k.MaxJailDuration(ctx)
+	if maxJail == 0 {
+		return 0, false, nil
+	}
+
+	jailTime, found := k.getLastJailTime(ctx, consAddr)
+	if !found {
+		jailTime = info.JailedUntil
+	}
+
+	remaining := jailTime.Add(maxJail).Sub(ctx.BlockHeader().Time)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true, nil
+}
+
+func (k Keeper) getLastUnjailHeight(ctx sdk.Context, consAddr sdk.ConsAddress) (int64, bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.LastUnjailHeightKey(consAddr))
+	if bz == nil {
+		return 0, false
+	}
+
+	var height gogotypes.Int64Value
+	k.cdc.MustUnmarshal(bz, &height)
+	return height.Value, true
+}
+
+func (k Keeper) setLastUnjailHeight(ctx sdk.Context, consAddr sdk.ConsAddress, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshal(&gogotypes.Int64Value{Value: height})
+	store.Set(types.LastUnjailHeightKey(consAddr), bz)
+}
+
+// UnjailEligibleHeight estimates the height from which a jailed validator can
+// be unjailed. The jailed until timestamp is converted to a height assuming
+// blocks are produced every types.ExpectedBlockTime, so the result is only an
+// approximation: the actual height depends on the real block times.
+func (k Keeper) UnjailEligibleHeight(ctx sdk.Context, consAddr sdk.ConsAddress) (int64, error) {
+	info, found := k.GetValidatorSigningInfo(ctx, consAddr)
+	if !found {
+		return 0, sdkerrors.Wrap(types.ErrNoSigningInfoFound, consAddr.String())
+	}
+
+	validator := k.Sk.ValidatorByConsAddr(ctx, consAddr)
+	if validator == nil {
+		return 0, sdkerrors.Wrap(types.ErrNoValidatorForAddress, consAddr.String())
+	}
+	if !validator.IsJailed() {
+		return 0, sdkerrors.Wrap(types.ErrValidatorNotJailed, consAddr.String())
+	}
+
+	remaining := info.JailedUntil.Sub(ctx.BlockHeader().Time)
+	if remaining <= 0 {
+		return ctx.BlockHeight(), nil
+	}
+
+	blocks := int64(remaining / types.ExpectedBlockTime)
+	if remaining%types.ExpectedBlockTime != 0 {
+		blocks++
+	}
+
+	return ctx.BlockHeight() + blocks, nil
+}
@@ -0,0 +1,162 @@
+package keeper_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing"
+	"gea-poa/x/slashing/types"
+)
+
+func TestUnjailEligibleHeight(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	ctx = ctx.WithBlockHeight(100)
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	_, err := k.UnjailEligibleHeight(ctx, consAddr)
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	jailedUntil := ctx.BlockTime().Add(time.Minute)
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 0, jailedUntil, false, 0))
+
+	_, err = k.UnjailEligibleHeight(ctx, consAddr)
+	require.ErrorIs(t, err, types.ErrValidatorNotJailed)
+
+	sk.Jail(ctx, consAddr)
+	height, err := k.UnjailEligibleHeight(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(100+time.Minute/types.ExpectedBlockTime), height)
+
+	// partial blocks round up
+	k.JailUntil(ctx, consAddr, jailedUntil.Add(time.Second))
+	height, err = k.UnjailEligibleHeight(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(100+time.Minute/types.ExpectedBlockTime+1), height)
+
+	// jail period is already over
+	ctx = ctx.WithBlockTime(jailedUntil.Add(time.Hour))
+	height, err = k.UnjailEligibleHeight(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), height)
+}
+
+func TestUnjailCooldown(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	ctx = ctx.WithBlockHeight(100)
+
+	params := k.GetParams(ctx)
+	params.UnjailCooldownBlocks = 10
+	k.SetParams(ctx, params)
+
+	pk := sk.addValidator()
+	valAddr := sdk.ValAddress(pk.Address())
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	// unjailing a validator that is not jailed records nothing
+	require.ErrorIs(t, k.Unjail(ctx, valAddr), types.ErrValidatorNotJailed)
+	sk.Jail(ctx, consAddr)
+	require.NoError(t, k.Unjail(ctx.WithBlockHeight(101), valAddr))
+
+	require.NoError(t, k.UnjailAfterJailPeriod(ctx, consAddr))
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	sk.Jail(ctx, consAddr)
+	require.ErrorIs(t, k.UnjailAfterJailPeriod(ctx.WithBlockHeight(109), consAddr), types.ErrUnjailCooldown)
+	require.ErrorIs(t, k.Unjail(ctx.WithBlockHeight(109), valAddr), types.ErrUnjailCooldown)
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+
+	require.NoError(t, k.UnjailAfterJailPeriod(ctx.WithBlockHeight(110), consAddr))
+	require.False(t, sk.byConsAddr(consAddr).IsJailed())
+
+	// the cooldown is per validator
+	other := sdk.ConsAddress(sk.addValidator().Address())
+	sk.Jail(ctx, other)
+	require.NoError(t, k.UnjailAfterJailPeriod(ctx.WithBlockHeight(115), other))
+}
+
+func TestTimeUntilRemoval(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+
+	pk := sk.addValidator()
+	consAddr := sdk.ConsAddress(pk.Address())
+
+	_, _, err := k.TimeUntilRemoval(ctx, consAddr)
+	require.ErrorIs(t, err, types.ErrNoSigningInfoFound)
+
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 0, time.Unix(0, 0), false, 0))
+
+	_, _, err = k.TimeUntilRemoval(ctx, consAddr)
+	require.ErrorIs(t, err, types.ErrValidatorNotJailed)
+
+	// removal is disabled by default
+	require.NoError(t, k.JailFor(ctx, consAddr, time.Hour))
+	remaining, enabled, err := k.TimeUntilRemoval(ctx, consAddr)
+	require.NoError(t, err)
+	require.False(t, enabled)
+	require.Zero(t, remaining)
+
+	params := k.GetParams(ctx)
+	params.MaxJailDuration = 24 * time.Hour
+	k.SetParams(ctx, params)
+
+	// the countdown starts at the jailing, not at the jailed until time
+	remaining, enabled, err = k.TimeUntilRemoval(ctx, consAddr)
+	require.NoError(t, err)
+	require.True(t, enabled)
+	require.Equal(t, 24*time.Hour, remaining)
+
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(20 * time.Hour))
+	remaining, _, err = k.TimeUntilRemoval(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, 4*time.Hour, remaining)
+
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(5 * time.Hour))
+	remaining, enabled, err = k.TimeUntilRemoval(ctx, consAddr)
+	require.NoError(t, err)
+	require.True(t, enabled)
+	require.Zero(t, remaining)
+
+	// validators jailed before jail times were recorded count from jailed until
+	legacy := sdk.ConsAddress(sk.addValidator().Address())
+	k.SetValidatorSigningInfo(ctx, legacy, types.NewValidatorSigningInfo(legacy, 1, 0, ctx.BlockTime().Add(time.Hour), false, 0))
+	sk.Jail(ctx, legacy)
+	remaining, _, err = k.TimeUntilRemoval(ctx, legacy)
+	require.NoError(t, err)
+	require.Equal(t, 25*time.Hour, remaining)
+}
+
+func TestTimeUntilRemovalAcrossJailedUntil(t *testing.T) {
+	ctx, k, sk := createTestInput(t)
+	consAddr := sdk.ConsAddress(sk.addValidator().Address())
+	k.SetValidatorSigningInfo(ctx, consAddr, types.NewValidatorSigningInfo(consAddr, 1, 0, time.Unix(0, 0), false, 0))
+
+	params := k.GetParams(ctx)
+	params.MaxJailDuration = 2 * time.Hour
+	params.UnjailCooldownBlocks = 100
+	k.SetParams(ctx, params)
+
+	// a validator within its unjail cooldown stays jailed past its jailed
+	// until time
+	require.NoError(t, k.UnjailAfterJailPeriod(ctx, consAddr))
+	require.NoError(t, k.JailFor(ctx, consAddr, time.Hour))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1).WithBlockTime(ctx.BlockTime().Add(90 * time.Minute))
+	slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	require.True(t, sk.byConsAddr(consAddr).IsJailed())
+	remaining, _, err := k.TimeUntilRemoval(ctx, consAddr)
+	require.NoError(t, err)
+	require.Equal(t, 30*time.Minute, remaining)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1).WithBlockTime(ctx.BlockTime().Add(time.Hour))
+	slashing.BeginBlocker(ctx, abci.RequestBeginBlock{}, k)
+	remaining, _, err = k.TimeUntilRemoval(ctx, consAddr)
+	require.NoError(t, err)
+	require.Zero(t, remaining)
+}
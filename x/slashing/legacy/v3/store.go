@@ -0,0 +1,25 @@
+package v3
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+// MigrateParams performs in-place params migrations from version 2 to 3. The
+// migration includes:
+//
+// - Set the SlashFractionDoubleSign and SlashFractionDowntime params to their
+// defaults, which keep validators being jailed without slashing their tokens.
+// - Set the UnjailCooldownBlocks param to its default, which disables the
+// cooldown between two unjails of a validator.
+// - Set the MaxJailDuration param to its default, which disables the removal
+// of validators jailed for too long.
+func MigrateParams(ctx sdk.Context, paramspace types.ParamSubspace) error {
+	paramspace.Set(ctx, types.KeySlashFractionDoubleSign, types.DefaultSlashFractionDoubleSign)
+	paramspace.Set(ctx, types.KeySlashFractionDowntime, types.DefaultSlashFractionDowntime)
+	paramspace.Set(ctx, types.KeyUnjailCooldownBlocks, types.DefaultUnjailCooldownBlocks)
+	paramspace.Set(ctx, types.KeyMaxJailDuration, types.DefaultMaxJailDuration)
+
+	return nil
+}
@@ -40,6 +40,86 @@ func NewDecodeStore(cdc codec.BinaryCodec) func(kvA, kvB kv.Pair) string {
 			}
 			return fmt.Sprintf("PubKeyA: %s\nPubKeyB: %s", pubKeyA, pubKeyB)
 
+		case bytes.Equal(kvA.Key[:1], types.ValidatorDowntimeStrikesKeyPrefix):
+			var strikesA, strikesB gogotypes.Int64Value
+			cdc.MustUnmarshal(kvA.Value, &strikesA)
+			cdc.MustUnmarshal(kvB.Value, &strikesB)
+			return fmt.Sprintf("strikesA: %d\nstrikesB: %d", strikesA.Value, strikesB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.ValidatorSlashExemptKeyPrefix):
+			var exemptA, exemptB gogotypes.BoolValue
+			cdc.MustUnmarshal(kvA.Value, &exemptA)
+			cdc.MustUnmarshal(kvB.Value, &exemptB)
+			return fmt.Sprintf("exemptA: %v\nexemptB: %v", exemptA.Value, exemptB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.SlashRecordKeyPrefix):
+			var recordA, recordB types.SlashRecord
+			cdc.MustUnmarshal(kvA.Value, &recordA)
+			cdc.MustUnmarshal(kvB.Value, &recordB)
+			return fmt.Sprintf("%v\n%v", recordA, recordB)
+
+		case bytes.Equal(kvA.Key[:1], types.DowntimeJailDurationKeyPrefix):
+			var durationA, durationB gogotypes.Duration
+			cdc.MustUnmarshal(kvA.Value, &durationA)
+			cdc.MustUnmarshal(kvB.Value, &durationB)
+			return fmt.Sprintf("durationA: %s\ndurationB: %s", &durationA, &durationB)
+
+		case bytes.Equal(kvA.Key[:1], types.LastSignedHeightKeyPrefix):
+			var heightA, heightB gogotypes.Int64Value
+			cdc.MustUnmarshal(kvA.Value, &heightA)
+			cdc.MustUnmarshal(kvB.Value, &heightB)
+			return fmt.Sprintf("heightA: %d\nheightB: %d", heightA.Value, heightB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.AuthorityKeyPrefix):
+			var authorityA, authorityB gogotypes.BoolValue
+			cdc.MustUnmarshal(kvA.Value, &authorityA)
+			cdc.MustUnmarshal(kvB.Value, &authorityB)
+			return fmt.Sprintf("authorityA: %v\nauthorityB: %v", authorityA.Value, authorityB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.PenaltySummaryKeyPrefix):
+			var summaryA, summaryB types.PenaltySummary
+			cdc.MustUnmarshal(kvA.Value, &summaryA)
+			cdc.MustUnmarshal(kvB.Value, &summaryB)
+			return fmt.Sprintf("%v\n%v", summaryA, summaryB)
+
+		case bytes.Equal(kvA.Key[:1], types.DowntimeJailingPausedKey):
+			var pausedA, pausedB gogotypes.BoolValue
+			cdc.MustUnmarshal(kvA.Value, &pausedA)
+			cdc.MustUnmarshal(kvB.Value, &pausedB)
+			return fmt.Sprintf("pausedA: %v\npausedB: %v", pausedA.Value, pausedB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.JailEventKeyPrefix):
+			heightA, addrA := types.ParseJailEventKey(kvA.Key)
+			heightB, addrB := types.ParseJailEventKey(kvB.Key)
+			return fmt.Sprintf("jailA: %s at %d\njailB: %s at %d", addrA, heightA, addrB, heightB)
+
+		case bytes.Equal(kvA.Key[:1], types.LastUnjailHeightKeyPrefix):
+			var heightA, heightB gogotypes.Int64Value
+			cdc.MustUnmarshal(kvA.Value, &heightA)
+			cdc.MustUnmarshal(kvB.Value, &heightB)
+			return fmt.Sprintf("heightA: %d\nheightB: %d", heightA.Value, heightB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.UptimeStreakKeyPrefix):
+			var streakA, streakB gogotypes.Int64Value
+			cdc.MustUnmarshal(kvA.Value, &streakA)
+			cdc.MustUnmarshal(kvB.Value, &streakB)
+			return fmt.Sprintf("streakA: %d\nstreakB: %d", streakA.Value, streakB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.SlashRecordTimeKeyPrefix):
+			return fmt.Sprintf("recordA: %X\nrecordB: %X", kvA.Value, kvB.Value)
+
+		case bytes.Equal(kvA.Key[:1], types.AppliedParamsKey):
+			var paramsA, paramsB types.Params
+			cdc.MustUnmarshal(kvA.Value, &paramsA)
+			cdc.MustUnmarshal(kvB.Value, &paramsB)
+			return fmt.Sprintf("%v\n%v", paramsA, paramsB)
+
+		case bytes.Equal(kvA.Key[:1], types.LastJailTimeKeyPrefix):
+			var timeA, timeB gogotypes.Timestamp
+			cdc.MustUnmarshal(kvA.Value, &timeA)
+			cdc.MustUnmarshal(kvB.Value, &timeB)
+			return fmt.Sprintf("timeA: %s\ntimeB: %s", &timeA, &timeB)
+
 		default:
 			panic(fmt.Sprintf("invalid slashing key prefix %X", kvA.Key[:1]))
 		}
@@ -22,6 +22,8 @@ const (
 	DowntimeJailDuration    = "downtime_jail_duration"
 	SlashFractionDoubleSign = "slash_fraction_double_sign"
 	SlashFractionDowntime   = "slash_fraction_downtime"
+	UnjailCooldownBlocks    = "unjail_cooldown_blocks"
+	MaxJailDuration         = "max_jail_duration"
 )
 
 // GenSignedBlocksWindow randomized SignedBlocksWindow
@@ -49,6 +51,16 @@ func GenSlashFractionDowntime(r *rand.Rand) sdk.Dec {
 	return sdk.NewDec(1).Quo(sdk.NewDec(int64(r.Intn(200) + 1)))
 }
 
+// GenUnjailCooldownBlocks randomized UnjailCooldownBlocks
+func GenUnjailCooldownBlocks(r *rand.Rand) int64 {
+	return int64(r.Intn(100))
+}
+
+// GenMaxJailDuration randomized MaxJailDuration
+func GenMaxJailDuration(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 0, 60*60*24*7)) * time.Second
+}
+
 // RandomizedGenState generates a random GenesisState for slashing
 func RandomizedGenState(simState *module.SimulationState) {
 	var signedBlocksWindow int64
@@ -69,7 +81,32 @@ func RandomizedGenState(simState *module.SimulationState) {
 		func(r *rand.Rand) { downtimeJailDuration = GenDowntimeJailDuration(r) },
 	)
 
-	params := types.NewParams(signedBlocksWindow, minSignedPerWindow, downtimeJailDuration)
+	var slashFractionDoubleSign sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, SlashFractionDoubleSign, &slashFractionDoubleSign, simState.Rand,
+		func(r *rand.Rand) { slashFractionDoubleSign = GenSlashFractionDoubleSign(r) },
+	)
+
+	var slashFractionDowntime sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, SlashFractionDowntime, &slashFractionDowntime, simState.Rand,
+		func(r *rand.Rand) { slashFractionDowntime = GenSlashFractionDowntime(r) },
+	)
+
+	var unjailCooldownBlocks int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, UnjailCooldownBlocks, &unjailCooldownBlocks, simState.Rand,
+		func(r *rand.Rand) { unjailCooldownBlocks = GenUnjailCooldownBlocks(r) },
+	)
+
+	var maxJailDuration time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxJailDuration, &maxJailDuration, simState.Rand,
+		func(r *rand.Rand) { maxJailDuration = GenMaxJailDuration(r) },
+	)
+
+	params := types.NewParams(signedBlocksWindow, minSignedPerWindow, downtimeJailDuration,
+		slashFractionDoubleSign, slashFractionDowntime, unjailCooldownBlocks, maxJailDuration)
 
 	slashingGenesis := types.NewGenesisState(params, []types.SigningInfo{}, []types.ValidatorMissedBlocks{})
 
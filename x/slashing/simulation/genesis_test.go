@@ -48,6 +48,10 @@ func TestRandomizedGenState(t *testing.T) {
 	require.Equal(t, dec1, slashingGenesis.Params.MinSignedPerWindow)
 	require.Equal(t, int64(720), slashingGenesis.Params.SignedBlocksWindow)
 	require.Equal(t, time.Duration(34800000000000), slashingGenesis.Params.DowntimeJailDuration)
+	require.True(t, slashingGenesis.Params.SlashFractionDoubleSign.IsPositive())
+	require.True(t, slashingGenesis.Params.SlashFractionDowntime.IsPositive())
+	require.GreaterOrEqual(t, slashingGenesis.Params.UnjailCooldownBlocks, int64(0))
+	require.GreaterOrEqual(t, slashingGenesis.Params.MaxJailDuration, time.Duration(0))
 	require.Len(t, slashingGenesis.MissedBlocks, 0)
 	require.Len(t, slashingGenesis.SigningInfos, 0)
 
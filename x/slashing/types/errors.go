@@ -13,4 +13,14 @@ var (
 	ErrMissingSelfDelegation        = sdkerrors.Register(ModuleName, 1005, "validator has no self-delegation; cannot be unjailed")
 	ErrSelfDelegationTooLowToUnjail = sdkerrors.Register(ModuleName, 1006, "validator's self delegation less than minimum; cannot be unjailed")
 	ErrNoSigningInfoFound           = sdkerrors.Register(ModuleName, 1007, "no validator signing info found")
+	ErrInvalidSlashPower            = sdkerrors.Register(ModuleName, 1008, "slash power must be positive")
+	ErrInvalidSlashFraction         = sdkerrors.Register(ModuleName, 1009, "slash fraction must be positive")
+	ErrPubkeyInUse                  = sdkerrors.Register(ModuleName, 1010, "consensus pubkey already in use")
+	ErrValidatorNotTombstoned       = sdkerrors.Register(ModuleName, 1011, "validator not tombstoned")
+	ErrEmptyAuthoritySet            = sdkerrors.Register(ModuleName, 1012, "authority set is empty")
+	ErrUnjailCooldown               = sdkerrors.Register(ModuleName, 1013, "validator unjailed too recently; cannot be unjailed")
+	ErrValidatorTombstoned          = sdkerrors.Register(ModuleName, 1014, "validator already tombstoned")
+	ErrNoBondedTokens               = sdkerrors.Register(ModuleName, 1015, "staking keeper holds no bonded tokens; cannot slash")
+	ErrInvalidJailDuration          = sdkerrors.Register(ModuleName, 1016, "jail duration must be positive")
+	ErrValidatorAlreadyJailed       = sdkerrors.Register(ModuleName, 1017, "validator already jailed")
 )
@@ -2,8 +2,14 @@ package types
 
 // Slashing module event types
 const (
-	EventTypeSlash    = "slash"
-	EventTypeLiveness = "liveness"
+	EventTypeSlash        = "slash"
+	EventTypeSlashSkipped = "slash_skipped"
+	EventTypeLiveness     = "liveness"
+
+	EventTypeMissedBlocksReset = "missed_blocks_reset"
+	EventTypeParamsUpdated     = "params_updated"
+	EventTypeTombstoneCleared  = "tombstone_cleared"
+	EventTypeDowntimeJailing   = "downtime_jailing"
 
 	AttributeKeyAddress      = "address"
 	AttributeKeyHeight       = "height"
@@ -11,8 +17,15 @@ const (
 	AttributeKeyReason       = "reason"
 	AttributeKeyJailed       = "jailed"
 	AttributeKeyMissedBlocks = "missed_blocks"
+	AttributeKeyValidators   = "validators"
+	AttributeKeyParam        = "param"
+	AttributeKeyPaused       = "paused"
+	AttributeKeyDuration     = "duration"
 
 	AttributeValueDoubleSign       = "double_sign"
 	AttributeValueMissingSignature = "missing_signature"
+	AttributeValueSlashExempt      = "slash_exempt"
+	AttributeValueDeauthorized     = "deauthorized"
+	AttributeValueTombstoned       = "tombstoned"
 	AttributeValueCategory         = ModuleName
 )
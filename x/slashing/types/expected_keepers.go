@@ -3,6 +3,8 @@
 package types
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
@@ -28,7 +30,9 @@ type ParamSubspace interface {
 	HasKeyTable() bool
 	WithKeyTable(table paramtypes.KeyTable) paramtypes.Subspace
 	Get(ctx sdk.Context, key []byte, ptr interface{})
+	GetRaw(ctx sdk.Context, key []byte) []byte
 	GetParamSet(ctx sdk.Context, ps paramtypes.ParamSet)
+	Set(ctx sdk.Context, key []byte, value interface{})
 	SetParamSet(ctx sdk.Context, ps paramtypes.ParamSet)
 }
 
@@ -48,6 +52,23 @@ type StakingKeeper interface {
 	MaxValidators(sdk.Context) uint32
 }
 
+// BondedStakingKeeper expected staking keeper of validators holding bonded
+// tokens. srstaking validators hold none, so tokens are only slashed when the
+// StakingKeeper also implements this interface.
+type BondedStakingKeeper interface {
+	StakingKeeper
+
+	// slash the validator and delegators of the validator, specifying offence height, offence power, and slash fraction,
+	// returns the amount of tokens burned
+	Slash(sdk.Context, sdk.ConsAddress, int64, int64, sdk.Dec) sdk.Int
+
+	// UnbondingTime returns the unbonding period
+	UnbondingTime(sdk.Context) time.Duration
+
+	// BondDenom returns the denom of the staked tokens
+	BondDenom(sdk.Context) string
+}
+
 // StakingHooks event hooks for srstaking validator object (noalias)
 type StakingHooks interface {
 	AfterValidatorCreated(ctx sdk.Context, valAddr sdk.ValAddress)                          // Must be called when a validator is created
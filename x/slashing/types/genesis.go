@@ -43,6 +43,26 @@ func ValidateGenesis(data GenesisState) error {
 		return fmt.Errorf("min signed per window should be less than or equal to one and greater than zero, is %s", minSign.String())
 	}
 
+	slashFractionDoubleSign := data.Params.SlashFractionDoubleSign
+	if slashFractionDoubleSign.IsNegative() || slashFractionDoubleSign.GT(sdk.OneDec()) {
+		return fmt.Errorf("slash fraction double sign should be between zero and one, inclusive, is %s", slashFractionDoubleSign.String())
+	}
+
+	slashFractionDowntime := data.Params.SlashFractionDowntime
+	if slashFractionDowntime.IsNegative() || slashFractionDowntime.GT(sdk.OneDec()) {
+		return fmt.Errorf("slash fraction downtime should be between zero and one, inclusive, is %s", slashFractionDowntime.String())
+	}
+
+	unjailCooldown := data.Params.UnjailCooldownBlocks
+	if unjailCooldown < 0 {
+		return fmt.Errorf("unjail cooldown blocks cannot be negative, is %d", unjailCooldown)
+	}
+
+	maxJail := data.Params.MaxJailDuration
+	if maxJail < 0 {
+		return fmt.Errorf("max jail duration cannot be negative, is %s", maxJail.String())
+	}
+
 	downtimeJail := data.Params.DowntimeJailDuration
 	if downtimeJail < 1*time.Minute {
 		return fmt.Errorf("downtime unjail duration must be at least 1 minute, is %s", downtimeJail.String())
@@ -53,5 +73,41 @@ func ValidateGenesis(data GenesisState) error {
 		return fmt.Errorf("signed blocks window must be at least 10, is %d", signedWindow)
 	}
 
+	seen := make(map[string]bool, len(data.SigningInfos))
+	for _, info := range data.SigningInfos {
+		if err := validateGenesisSigningInfo(info, signedWindow); err != nil {
+			return err
+		}
+		if seen[info.Address] {
+			return fmt.Errorf("duplicate signing info for validator %s", info.Address)
+		}
+		seen[info.Address] = true
+	}
+
+	return nil
+}
+
+// validateGenesisSigningInfo checks a genesis signing info has a valid
+// consensus address, counters within the signed blocks window and a jail
+// period if tombstoned.
+func validateGenesisSigningInfo(info SigningInfo, signedWindow int64) error {
+	if _, err := sdk.ConsAddressFromBech32(info.Address); err != nil {
+		return fmt.Errorf("invalid signing info address %s: %w", info.Address, err)
+	}
+
+	signInfo := info.ValidatorSigningInfo
+	if signInfo.Address != info.Address {
+		return fmt.Errorf("signing info of validator %s is for validator %s", info.Address, signInfo.Address)
+	}
+	if signInfo.StartHeight < 0 || signInfo.IndexOffset < 0 {
+		return fmt.Errorf("signing info of validator %s has a negative start height or index offset", info.Address)
+	}
+	if signInfo.MissedBlocksCounter < 0 || signInfo.MissedBlocksCounter > signedWindow {
+		return fmt.Errorf("signing info of validator %s missed blocks counter must be within [0, %d], is %d", info.Address, signedWindow, signInfo.MissedBlocksCounter)
+	}
+	if signInfo.Tombstoned && !signInfo.JailedUntil.After(time.Unix(0, 0)) {
+		return fmt.Errorf("signing info of validator %s is tombstoned but not jailed", info.Address)
+	}
+
 	return nil
 }
@@ -0,0 +1,77 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"gea-poa/x/slashing/types"
+)
+
+func TestValidateGenesisSigningInfos(t *testing.T) {
+	addr := sdk.ConsAddress("validator_address___")
+	other := sdk.ConsAddress("other_address_______")
+	info := types.NewValidatorSigningInfo(addr, 1, 0, time.Unix(0, 0), false, 0)
+
+	genesisWith := func(infos ...types.SigningInfo) types.GenesisState {
+		return *types.NewGenesisState(types.DefaultParams(), infos, nil)
+	}
+	withInfo := func(update func(info *types.ValidatorSigningInfo)) types.SigningInfo {
+		signInfo := info
+		update(&signInfo)
+		return types.SigningInfo{Address: addr.String(), ValidatorSigningInfo: signInfo}
+	}
+	valid := withInfo(func(*types.ValidatorSigningInfo) {})
+
+	require.NoError(t, types.ValidateGenesis(genesisWith(valid)))
+
+	tombstoned := withInfo(func(i *types.ValidatorSigningInfo) {
+		i.Tombstoned = true
+		i.JailedUntil = time.Unix(253402300799, 0)
+	})
+	require.NoError(t, types.ValidateGenesis(genesisWith(tombstoned)))
+
+	for _, tc := range []struct {
+		name  string
+		infos []types.SigningInfo
+		err   string
+	}{
+		{
+			"invalid address",
+			[]types.SigningInfo{{Address: "invalid", ValidatorSigningInfo: info}},
+			"invalid signing info address invalid: decoding bech32 failed: invalid bech32 string length 7",
+		},
+		{
+			"mismatched address",
+			[]types.SigningInfo{withInfo(func(i *types.ValidatorSigningInfo) { i.Address = other.String() })},
+			"signing info of validator " + addr.String() + " is for validator " + other.String(),
+		},
+		{
+			"negative index offset",
+			[]types.SigningInfo{withInfo(func(i *types.ValidatorSigningInfo) { i.IndexOffset = -1 })},
+			"signing info of validator " + addr.String() + " has a negative start height or index offset",
+		},
+		{
+			"counter past window",
+			[]types.SigningInfo{withInfo(func(i *types.ValidatorSigningInfo) { i.MissedBlocksCounter = types.DefaultSignedBlocksWindow + 1 })},
+			"signing info of validator " + addr.String() + " missed blocks counter must be within [0, 100], is 101",
+		},
+		{
+			"tombstoned not jailed",
+			[]types.SigningInfo{withInfo(func(i *types.ValidatorSigningInfo) { i.Tombstoned = true })},
+			"signing info of validator " + addr.String() + " is tombstoned but not jailed",
+		},
+		{
+			"duplicate",
+			[]types.SigningInfo{valid, valid},
+			"duplicate signing info for validator " + addr.String(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.EqualError(t, types.ValidateGenesis(genesisWith(tc.infos...)), tc.err)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package types
+
+// EventReason returns the value of the slash event reason attribute for the
+// infraction.
+func (i Infraction) EventReason() string {
+	switch i {
+	case InfractionDoubleSign:
+		return AttributeValueDoubleSign
+	case InfractionDowntime:
+		return AttributeValueMissingSignature
+	default:
+		return i.String()
+	}
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/binary"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
@@ -15,6 +16,9 @@ const (
 	// StoreKey is the store key string for slashing
 	StoreKey = ModuleName
 
+	// TStoreKey is the transient store key string for slashing
+	TStoreKey = "transient_" + ModuleName
+
 	// RouterKey is the message route for slashing
 	RouterKey = ModuleName
 
@@ -30,10 +34,53 @@ const (
 // - 0x02<consAddrLen (1 Byte)><consAddress_Bytes><period_Bytes>: bool
 //
 // - 0x03<accAddrLen (1 Byte)><accAddr_Bytes>: cryptotypes.PubKey
+//
+// - 0x04<consAddrLen (1 Byte)><consAddress_Bytes>: int64
+//
+// - 0x05<consAddrLen (1 Byte)><consAddress_Bytes>: bool
+//
+// - 0x06<consAddrLen (1 Byte)><consAddress_Bytes><height_Bytes><sequence_Bytes>: SlashRecord
+//
+// - 0x08<consAddrLen (1 Byte)><consAddress_Bytes>: time.Duration
+//
+// - 0x09<consAddrLen (1 Byte)><consAddress_Bytes>: int64
+//
+// - 0x0A<consAddrLen (1 Byte)><consAddress_Bytes>: bool
+//
+// - 0x0B<consAddrLen (1 Byte)><consAddress_Bytes>: PenaltySummary
+//
+// - 0x0C: bool
+//
+// - 0x0D<height_Bytes><consAddrLen (1 Byte)><consAddress_Bytes>: []byte{}
+//
+// - 0x0E<consAddrLen (1 Byte)><consAddress_Bytes>: int64
+//
+// - 0x0F<consAddrLen (1 Byte)><consAddress_Bytes>: int64
+//
+// - 0x10<time_Bytes><consAddrLen (1 Byte)><consAddress_Bytes><height_Bytes><sequence_Bytes>: []byte (SlashRecord key)
+//
+// - 0x11: Params
+//
+// - 0x12<consAddrLen (1 Byte)><consAddress_Bytes>: time.Time
 var (
 	ValidatorSigningInfoKeyPrefix         = []byte{0x01} // Prefix for signing info
 	ValidatorMissedBlockBitArrayKeyPrefix = []byte{0x02} // Prefix for missed block bit array
 	AddrPubkeyRelationKeyPrefix           = []byte{0x03} // Prefix for address-pubkey relation
+	ValidatorDowntimeStrikesKeyPrefix     = []byte{0x04} // Prefix for downtime strike counter
+	ValidatorSlashExemptKeyPrefix         = []byte{0x05} // Prefix for validators exempt from downtime slashing
+	SlashRecordKeyPrefix                  = []byte{0x06} // Prefix for slash records
+	SlashedThisBlockKeyPrefix             = []byte{0x07} // Prefix for the tokens slashed in the current block, in the transient store
+	DowntimeJailDurationKeyPrefix         = []byte{0x08} // Prefix for per-validator downtime jail duration overrides
+	LastSignedHeightKeyPrefix             = []byte{0x09} // Prefix for the last height a validator signed
+	AuthorityKeyPrefix                    = []byte{0x0A} // Prefix for the PoA authority set
+	PenaltySummaryKeyPrefix               = []byte{0x0B} // Prefix for lifetime penalty counters
+	DowntimeJailingPausedKey              = []byte{0x0C} // Key for the downtime jailing pause flag
+	JailEventKeyPrefix                    = []byte{0x0D} // Prefix for the jailing index by height
+	LastUnjailHeightKeyPrefix             = []byte{0x0E} // Prefix for the last height a validator was unjailed
+	UptimeStreakKeyPrefix                 = []byte{0x0F} // Prefix for the consecutive signed blocks counter
+	SlashRecordTimeKeyPrefix              = []byte{0x10} // Prefix for the slash records index by time
+	AppliedParamsKey                      = []byte{0x11} // Key for the params last applied by ApplyParamChanges
+	LastJailTimeKeyPrefix                 = []byte{0x12} // Prefix for the time a validator was last jailed
 )
 
 // ValidatorSigningInfoKey - stored by *Consensus* address (not operator address)
@@ -63,7 +110,135 @@ func ValidatorMissedBlockBitArrayKey(v sdk.ConsAddress, i int64) []byte {
 	return append(ValidatorMissedBlockBitArrayPrefixKey(v), b...)
 }
 
+// ValidatorDowntimeStrikesKey - stored by *Consensus* address (not operator address)
+func ValidatorDowntimeStrikesKey(v sdk.ConsAddress) []byte {
+	return append(ValidatorDowntimeStrikesKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// ValidatorSlashExemptKey - stored by *Consensus* address (not operator address)
+func ValidatorSlashExemptKey(v sdk.ConsAddress) []byte {
+	return append(ValidatorSlashExemptKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// DowntimeJailDurationKey - stored by *Consensus* address (not operator address)
+func DowntimeJailDurationKey(v sdk.ConsAddress) []byte {
+	return append(DowntimeJailDurationKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// LastSignedHeightKey - stored by *Consensus* address (not operator address)
+func LastSignedHeightKey(v sdk.ConsAddress) []byte {
+	return append(LastSignedHeightKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// AuthorityKey - stored by *Consensus* address (not operator address)
+func AuthorityKey(v sdk.ConsAddress) []byte {
+	return append(AuthorityKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// PenaltySummaryKey - stored by *Consensus* address (not operator address)
+func PenaltySummaryKey(v sdk.ConsAddress) []byte {
+	return append(PenaltySummaryKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// LastUnjailHeightKey - stored by *Consensus* address (not operator address)
+func LastUnjailHeightKey(v sdk.ConsAddress) []byte {
+	return append(LastUnjailHeightKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// LastJailTimeKey - stored by *Consensus* address (not operator address)
+func LastJailTimeKey(v sdk.ConsAddress) []byte {
+	return append(LastJailTimeKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// UptimeStreakKey - stored by *Consensus* address (not operator address)
+func UptimeStreakKey(v sdk.ConsAddress) []byte {
+	return append(UptimeStreakKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// UptimeStreakAddress - extract the address from an uptime streak key
+func UptimeStreakAddress(key []byte) (v sdk.ConsAddress) {
+	// Remove prefix and address length.
+	kv.AssertKeyAtLeastLength(key, 3)
+	addr := key[2:]
+
+	return sdk.ConsAddress(addr)
+}
+
+// JailEventHeightPrefixKey - stored by the height the validator was jailed at
+func JailEventHeightPrefixKey(height int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+
+	return append(JailEventKeyPrefix, b...)
+}
+
+// JailEventKey - stored by the height the validator was jailed at and its
+// *Consensus* address (not operator address)
+func JailEventKey(height int64, v sdk.ConsAddress) []byte {
+	return append(JailEventHeightPrefixKey(height), address.MustLengthPrefix(v.Bytes())...)
+}
+
+// ParseJailEventKey - extract the height and address from a jail event key
+func ParseJailEventKey(key []byte) (height int64, v sdk.ConsAddress) {
+	// Remove prefix, height and address length.
+	kv.AssertKeyAtLeastLength(key, 11)
+	height = int64(binary.BigEndian.Uint64(key[1:9]))
+	addr := key[10:]
+
+	return height, sdk.ConsAddress(addr)
+}
+
+// SlashRecordPrefixKey - stored by *Consensus* address (not operator address)
+func SlashRecordPrefixKey(v sdk.ConsAddress) []byte {
+	return append(SlashRecordKeyPrefix, address.MustLengthPrefix(v.Bytes())...)
+}
+
+// SlashRecordHeightPrefixKey - stored by *Consensus* address (not operator
+// address) and the height the validator was slashed at
+func SlashRecordHeightPrefixKey(v sdk.ConsAddress, height int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+
+	return append(SlashRecordPrefixKey(v), b...)
+}
+
+// SlashRecordKey - stored by *Consensus* address (not operator address), the
+// height the validator was slashed at and the sequence of the slash within
+// that height
+func SlashRecordKey(v sdk.ConsAddress, height int64, sequence uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, sequence)
+
+	return append(SlashRecordHeightPrefixKey(v, height), b...)
+}
+
+// SlashRecordTimePrefixKey - stored by the time the validator was slashed at
+func SlashRecordTimePrefixKey(t time.Time) []byte {
+	return append(SlashRecordTimeKeyPrefix, sdk.FormatTimeBytes(t)...)
+}
+
+// SlashRecordTimeKey - stored by the time the validator was slashed at and the
+// key of the slash record
+func SlashRecordTimeKey(t time.Time, recordKey []byte) []byte {
+	return append(SlashRecordTimePrefixKey(t), recordKey[len(SlashRecordKeyPrefix):]...)
+}
+
+// SlashedThisBlockKey - stored by denom in the transient store
+func SlashedThisBlockKey(denom string) []byte {
+	return append(SlashedThisBlockKeyPrefix, []byte(denom)...)
+}
+
 // AddrPubkeyRelationKey gets pubkey relation key used to get the pubkey from the address
 func AddrPubkeyRelationKey(addr []byte) []byte {
 	return append(AddrPubkeyRelationKeyPrefix, address.MustLengthPrefix(addr)...)
 }
+
+// AddrPubkeyRelationAddress - extract the address from an address-pubkey relation
+// key with the prefix already stripped
+func AddrPubkeyRelationAddress(key []byte) (v sdk.ConsAddress) {
+	// Remove address length.
+	kv.AssertKeyAtLeastLength(key, 2)
+	addr := key[1:]
+
+	return sdk.ConsAddress(addr)
+}
@@ -12,17 +12,40 @@ import (
 const (
 	DefaultSignedBlocksWindow   = int64(100)
 	DefaultDowntimeJailDuration = 60 * 5 * time.Second
+	DefaultUnjailCooldownBlocks = int64(0)
+	DefaultMaxJailDuration      = time.Duration(0)
 )
 
 var (
 	DefaultMinSignedPerWindow = sdk.NewDecWithPrec(5, 1)
+	// Validators are only jailed by default, slashing their tokens is opt-in.
+	DefaultSlashFractionDoubleSign = sdk.ZeroDec()
+	DefaultSlashFractionDowntime   = sdk.ZeroDec()
 )
 
+// ExpectedBlockTime is the block time used to estimate block heights from
+// durations and timestamps.
+const ExpectedBlockTime = 5 * time.Second
+
+// MaxDowntimeStrikeEscalation caps the exponent used to escalate the downtime
+// jail duration for repeat offenders, i.e. a jail lasts at most
+// DowntimeJailDuration * 2^MaxDowntimeStrikeEscalation.
+const MaxDowntimeStrikeEscalation = int64(6)
+
+// DoubleSignJailEndTime period ends at Max Time supported by Amino
+// (Dec 31, 9999 - 23:59:59 GMT), like its x/evidence counterpart. Validators
+// jailed until then are never unjailed by the BeginBlocker.
+var DoubleSignJailEndTime = time.Unix(253402300799, 0)
+
 // Parameter store keys
 var (
-	KeySignedBlocksWindow   = []byte("SignedBlocksWindow")
-	KeyMinSignedPerWindow   = []byte("MinSignedPerWindow")
-	KeyDowntimeJailDuration = []byte("DowntimeJailDuration")
+	KeySignedBlocksWindow      = []byte("SignedBlocksWindow")
+	KeyMinSignedPerWindow      = []byte("MinSignedPerWindow")
+	KeyDowntimeJailDuration    = []byte("DowntimeJailDuration")
+	KeySlashFractionDoubleSign = []byte("SlashFractionDoubleSign")
+	KeySlashFractionDowntime   = []byte("SlashFractionDowntime")
+	KeyUnjailCooldownBlocks    = []byte("UnjailCooldownBlocks")
+	KeyMaxJailDuration         = []byte("MaxJailDuration")
 )
 
 // ParamKeyTable for slashing module
@@ -33,12 +56,18 @@ func ParamKeyTable() paramtypes.KeyTable {
 // NewParams creates a new Params object
 func NewParams(
 	signedBlocksWindow int64, minSignedPerWindow sdk.Dec, downtimeJailDuration time.Duration,
+	slashFractionDoubleSign, slashFractionDowntime sdk.Dec, unjailCooldownBlocks int64,
+	maxJailDuration time.Duration,
 ) Params {
 
 	return Params{
-		SignedBlocksWindow: signedBlocksWindow,
-		MinSignedPerWindow: minSignedPerWindow,
-		DowntimeJailDuration: downtimeJailDuration,
+		SignedBlocksWindow:      signedBlocksWindow,
+		MinSignedPerWindow:      minSignedPerWindow,
+		DowntimeJailDuration:    downtimeJailDuration,
+		SlashFractionDoubleSign: slashFractionDoubleSign,
+		SlashFractionDowntime:   slashFractionDowntime,
+		UnjailCooldownBlocks:    unjailCooldownBlocks,
+		MaxJailDuration:         maxJailDuration,
 	}
 }
 
@@ -48,6 +77,10 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 		paramtypes.NewParamSetPair(KeySignedBlocksWindow, &p.SignedBlocksWindow, validateSignedBlocksWindow),
 		paramtypes.NewParamSetPair(KeyMinSignedPerWindow, &p.MinSignedPerWindow, validateMinSignedPerWindow),
 		paramtypes.NewParamSetPair(KeyDowntimeJailDuration, &p.DowntimeJailDuration, validateDowntimeJailDuration),
+		paramtypes.NewParamSetPair(KeySlashFractionDoubleSign, &p.SlashFractionDoubleSign, validateSlashFractionDoubleSign),
+		paramtypes.NewParamSetPair(KeySlashFractionDowntime, &p.SlashFractionDowntime, validateSlashFractionDowntime),
+		paramtypes.NewParamSetPair(KeyUnjailCooldownBlocks, &p.UnjailCooldownBlocks, validateUnjailCooldownBlocks),
+		paramtypes.NewParamSetPair(KeyMaxJailDuration, &p.MaxJailDuration, validateMaxJailDuration),
 	}
 }
 
@@ -55,6 +88,8 @@ func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 func DefaultParams() Params {
 	return NewParams(
 		DefaultSignedBlocksWindow, DefaultMinSignedPerWindow, DefaultDowntimeJailDuration,
+		DefaultSlashFractionDoubleSign, DefaultSlashFractionDowntime, DefaultUnjailCooldownBlocks,
+		DefaultMaxJailDuration,
 	)
 }
 
@@ -99,3 +134,61 @@ func validateDowntimeJailDuration(i interface{}) error {
 
 	return nil
 }
+
+func validateSlashFractionDoubleSign(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNegative() {
+		return fmt.Errorf("double sign slash fraction cannot be negative: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("double sign slash fraction too large: %s", v)
+	}
+
+	return nil
+}
+
+func validateSlashFractionDowntime(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNegative() {
+		return fmt.Errorf("downtime slash fraction cannot be negative: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("downtime slash fraction too large: %s", v)
+	}
+
+	return nil
+}
+
+func validateUnjailCooldownBlocks(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("unjail cooldown blocks cannot be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxJailDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("max jail duration cannot be negative: %s", v)
+	}
+
+	return nil
+}
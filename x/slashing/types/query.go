@@ -0,0 +1,34 @@
+package types
+
+import (
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+)
+
+var (
+	_ codectypes.UnpackInterfacesMessage = QueryPubkeyResponse{}
+	_ codectypes.UnpackInterfacesMessage = QueryPubkeysResponse{}
+	_ codectypes.UnpackInterfacesMessage = AddrPubkeyRelation{}
+)
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (r QueryPubkeyResponse) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var pk cryptotypes.PubKey
+	return unpacker.UnpackAny(r.Pubkey, &pk)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (r QueryPubkeysResponse) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	for _, relation := range r.Pubkeys {
+		if err := relation.UnpackInterfaces(unpacker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (r AddrPubkeyRelation) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	var pk cryptotypes.PubKey
+	return unpacker.UnpackAny(r.Pubkey, &pk)
+}
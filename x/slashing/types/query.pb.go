@@ -6,10 +6,12 @@ package types
 import (
 	context "context"
 	fmt "fmt"
+	types "github.com/cosmos/cosmos-sdk/codec/types"
 	query "github.com/cosmos/cosmos-sdk/types/query"
 	_ "github.com/gogo/protobuf/gogoproto"
 	grpc1 "github.com/gogo/protobuf/grpc"
 	proto "github.com/gogo/protobuf/proto"
+	_ "github.com/regen-network/cosmos-proto"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -307,6 +309,250 @@ func (m *QuerySigningInfosResponse) GetPagination() *query.PageResponse {
 	return nil
 }
 
+// QueryPubkeyRequest is the request type for the Query/Pubkey RPC method
+type QueryPubkeyRequest struct {
+	// cons_address is the address to query the pubkey of
+	ConsAddress string `protobuf:"bytes,1,opt,name=cons_address,json=consAddress,proto3" json:"cons_address,omitempty"`
+}
+
+func (m *QueryPubkeyRequest) Reset()         { *m = QueryPubkeyRequest{} }
+func (m *QueryPubkeyRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryPubkeyRequest) ProtoMessage()    {}
+func (*QueryPubkeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7cc17f43ee03fa6, []int{6}
+}
+func (m *QueryPubkeyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryPubkeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryPubkeyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryPubkeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPubkeyRequest.Merge(m, src)
+}
+func (m *QueryPubkeyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryPubkeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPubkeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPubkeyRequest proto.InternalMessageInfo
+
+func (m *QueryPubkeyRequest) GetConsAddress() string {
+	if m != nil {
+		return m.ConsAddress
+	}
+	return ""
+}
+
+// QueryPubkeyResponse is the response type for the Query/Pubkey RPC method
+type QueryPubkeyResponse struct {
+	// pubkey is the consensus pubkey stored for the requested cons address
+	Pubkey *types.Any `protobuf:"bytes,1,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+}
+
+func (m *QueryPubkeyResponse) Reset()         { *m = QueryPubkeyResponse{} }
+func (m *QueryPubkeyResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryPubkeyResponse) ProtoMessage()    {}
+func (*QueryPubkeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7cc17f43ee03fa6, []int{7}
+}
+func (m *QueryPubkeyResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryPubkeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryPubkeyResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryPubkeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPubkeyResponse.Merge(m, src)
+}
+func (m *QueryPubkeyResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryPubkeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPubkeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPubkeyResponse proto.InternalMessageInfo
+
+func (m *QueryPubkeyResponse) GetPubkey() *types.Any {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
+// QueryPubkeysRequest is the request type for the Query/Pubkeys RPC method
+type QueryPubkeysRequest struct {
+	Pagination *query.PageRequest `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryPubkeysRequest) Reset()         { *m = QueryPubkeysRequest{} }
+func (m *QueryPubkeysRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryPubkeysRequest) ProtoMessage()    {}
+func (*QueryPubkeysRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7cc17f43ee03fa6, []int{8}
+}
+func (m *QueryPubkeysRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryPubkeysRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryPubkeysRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryPubkeysRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPubkeysRequest.Merge(m, src)
+}
+func (m *QueryPubkeysRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryPubkeysRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPubkeysRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPubkeysRequest proto.InternalMessageInfo
+
+func (m *QueryPubkeysRequest) GetPagination() *query.PageRequest {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// QueryPubkeysResponse is the response type for the Query/Pubkeys RPC method
+type QueryPubkeysResponse struct {
+	// pubkeys is the address-pubkey relation of all validators
+	Pubkeys    []AddrPubkeyRelation `protobuf:"bytes,1,rep,name=pubkeys,proto3" json:"pubkeys"`
+	Pagination *query.PageResponse  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+}
+
+func (m *QueryPubkeysResponse) Reset()         { *m = QueryPubkeysResponse{} }
+func (m *QueryPubkeysResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryPubkeysResponse) ProtoMessage()    {}
+func (*QueryPubkeysResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7cc17f43ee03fa6, []int{9}
+}
+func (m *QueryPubkeysResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryPubkeysResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryPubkeysResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryPubkeysResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryPubkeysResponse.Merge(m, src)
+}
+func (m *QueryPubkeysResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryPubkeysResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryPubkeysResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryPubkeysResponse proto.InternalMessageInfo
+
+func (m *QueryPubkeysResponse) GetPubkeys() []AddrPubkeyRelation {
+	if m != nil {
+		return m.Pubkeys
+	}
+	return nil
+}
+
+func (m *QueryPubkeysResponse) GetPagination() *query.PageResponse {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+// AddrPubkeyRelation defines a stored consensus address to pubkey relation
+type AddrPubkeyRelation struct {
+	ConsAddress string     `protobuf:"bytes,1,opt,name=cons_address,json=consAddress,proto3" json:"cons_address,omitempty" yaml:"cons_address"`
+	Pubkey      *types.Any `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+}
+
+func (m *AddrPubkeyRelation) Reset()         { *m = AddrPubkeyRelation{} }
+func (m *AddrPubkeyRelation) String() string { return proto.CompactTextString(m) }
+func (*AddrPubkeyRelation) ProtoMessage()    {}
+func (*AddrPubkeyRelation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e7cc17f43ee03fa6, []int{10}
+}
+func (m *AddrPubkeyRelation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AddrPubkeyRelation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AddrPubkeyRelation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AddrPubkeyRelation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddrPubkeyRelation.Merge(m, src)
+}
+func (m *AddrPubkeyRelation) XXX_Size() int {
+	return m.Size()
+}
+func (m *AddrPubkeyRelation) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddrPubkeyRelation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddrPubkeyRelation proto.InternalMessageInfo
+
+func (m *AddrPubkeyRelation) GetConsAddress() string {
+	if m != nil {
+		return m.ConsAddress
+	}
+	return ""
+}
+
+func (m *AddrPubkeyRelation) GetPubkey() *types.Any {
+	if m != nil {
+		return m.Pubkey
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*QueryParamsRequest)(nil), "geapoa.slashing.QueryParamsRequest")
 	proto.RegisterType((*QueryParamsResponse)(nil), "geapoa.slashing.QueryParamsResponse")
@@ -314,44 +560,62 @@ func init() {
 	proto.RegisterType((*QuerySigningInfoResponse)(nil), "geapoa.slashing.QuerySigningInfoResponse")
 	proto.RegisterType((*QuerySigningInfosRequest)(nil), "geapoa.slashing.QuerySigningInfosRequest")
 	proto.RegisterType((*QuerySigningInfosResponse)(nil), "geapoa.slashing.QuerySigningInfosResponse")
+	proto.RegisterType((*QueryPubkeyRequest)(nil), "geapoa.slashing.QueryPubkeyRequest")
+	proto.RegisterType((*QueryPubkeyResponse)(nil), "geapoa.slashing.QueryPubkeyResponse")
+	proto.RegisterType((*QueryPubkeysRequest)(nil), "geapoa.slashing.QueryPubkeysRequest")
+	proto.RegisterType((*QueryPubkeysResponse)(nil), "geapoa.slashing.QueryPubkeysResponse")
+	proto.RegisterType((*AddrPubkeyRelation)(nil), "geapoa.slashing.AddrPubkeyRelation")
 }
 
 func init() { proto.RegisterFile("slashing/query.proto", fileDescriptor_e7cc17f43ee03fa6) }
 
 var fileDescriptor_e7cc17f43ee03fa6 = []byte{
-	// 512 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x94, 0xcf, 0x6b, 0x13, 0x41,
-	0x14, 0xc7, 0xb3, 0x6d, 0x0d, 0x38, 0x29, 0x2a, 0x63, 0x20, 0x71, 0x91, 0x6d, 0xbb, 0xfe, 0x68,
-	0x2d, 0x38, 0x63, 0x23, 0x7a, 0x12, 0xc4, 0x1e, 0x14, 0xc1, 0x43, 0x8d, 0xe8, 0xc1, 0x4b, 0x78,
-	0x69, 0xa6, 0xe3, 0xc2, 0x76, 0xde, 0x66, 0x67, 0x13, 0x2c, 0xe2, 0xc5, 0x3f, 0x40, 0x04, 0x6f,
-	0xde, 0xbd, 0xf9, 0x87, 0xf4, 0x58, 0xf0, 0xe2, 0x49, 0x24, 0xf1, 0x0f, 0x91, 0xcc, 0x4c, 0xd2,
-	0xa9, 0x5b, 0x4c, 0xbd, 0x0d, 0xef, 0xbd, 0xef, 0xfb, 0x7e, 0xde, 0xbe, 0xc7, 0x92, 0xba, 0x4e,
-	0x41, 0xbf, 0x49, 0x94, 0xe4, 0xfd, 0x81, 0xc8, 0x0f, 0x58, 0x96, 0x63, 0x81, 0xf4, 0xa2, 0x14,
-	0x90, 0x21, 0xb0, 0x69, 0x32, 0xdc, 0xdc, 0x45, 0xbd, 0x8f, 0x9a, 0x77, 0x41, 0x0b, 0x5b, 0xc9,
-	0x87, 0x5b, 0x5d, 0x51, 0xc0, 0x16, 0xcf, 0x40, 0x26, 0x0a, 0x8a, 0x04, 0x95, 0x15, 0x87, 0x75,
-	0x89, 0x12, 0xcd, 0x93, 0x4f, 0x5e, 0x2e, 0x7a, 0x55, 0x22, 0xca, 0x54, 0x70, 0xc8, 0x12, 0x0e,
-	0x4a, 0x61, 0x61, 0x24, 0xda, 0x65, 0x1b, 0x33, 0x8c, 0xe9, 0xc3, 0x26, 0xe2, 0x3a, 0xa1, 0xcf,
-	0x27, 0x76, 0x3b, 0x90, 0xc3, 0xbe, 0x6e, 0x8b, 0xfe, 0x40, 0xe8, 0x22, 0x7e, 0x46, 0x2e, 0x9f,
-	0x88, 0xea, 0x0c, 0x95, 0x16, 0xf4, 0x1e, 0xa9, 0x66, 0x26, 0xd2, 0x0c, 0x56, 0x83, 0x8d, 0x5a,
-	0xab, 0xc1, 0xfe, 0x9a, 0x83, 0x59, 0xc1, 0xf6, 0xd2, 0xe1, 0xcf, 0x95, 0x4a, 0xdb, 0x15, 0xc7,
-	0x0f, 0x48, 0xc3, 0x74, 0x7b, 0x91, 0x48, 0x95, 0x28, 0xf9, 0x54, 0xed, 0xa1, 0x33, 0xa2, 0x6b,
-	0x64, 0x79, 0x17, 0x95, 0xee, 0x40, 0xaf, 0x97, 0x0b, 0x6d, 0xfb, 0x9e, 0x6f, 0xd7, 0x26, 0xb1,
-	0x47, 0x36, 0x14, 0xf7, 0x49, 0xb3, 0xac, 0x76, 0x40, 0x2f, 0xc9, 0xa5, 0x21, 0xa4, 0x1d, 0x6d,
-	0x53, 0x9d, 0x44, 0xed, 0xa1, 0x43, 0xbb, 0x51, 0x42, 0x7b, 0x05, 0x69, 0xd2, 0x83, 0x02, 0x73,
-	0xaf, 0x91, 0x03, 0xbd, 0x30, 0x84, 0xd4, 0x8b, 0xc6, 0xdd, 0xb2, 0xe5, 0xf4, 0xd3, 0xd0, 0xc7,
-	0x84, 0x1c, 0x6f, 0xc4, 0x99, 0xdd, 0x64, 0x76, 0x7d, 0x6c, 0xb2, 0x3e, 0x66, 0x17, 0xed, 0xd6,
-	0xc7, 0x76, 0x40, 0x0a, 0xa7, 0x6d, 0x7b, 0xca, 0xf8, 0x6b, 0x40, 0xae, 0x9c, 0x62, 0xe2, 0x06,
-	0x7b, 0x48, 0x96, 0xdc, 0x30, 0x8b, 0xff, 0x3b, 0x8c, 0x11, 0xd2, 0x27, 0x27, 0x30, 0x17, 0x0c,
-	0xe6, 0xfa, 0x5c, 0x4c, 0xeb, 0xee, 0x73, 0xb6, 0xbe, 0x2d, 0x92, 0x73, 0x86, 0x93, 0x0e, 0x48,
-	0xd5, 0xae, 0x97, 0x5e, 0x2b, 0xf1, 0x94, 0x6f, 0x28, 0xbc, 0xfe, 0xef, 0x22, 0x6b, 0x15, 0xaf,
-	0x7e, 0xf8, 0xfe, 0xfb, 0xf3, 0x42, 0x48, 0x9b, 0x5c, 0x0a, 0xb8, 0x9d, 0x21, 0xcc, 0x0e, 0x94,
-	0xdb, 0xeb, 0xa1, 0x5f, 0x02, 0x52, 0xf3, 0xa6, 0xa4, 0x1b, 0xa7, 0xf7, 0x2d, 0x1f, 0x57, 0x78,
-	0xeb, 0x0c, 0x95, 0x0e, 0xe3, 0xbe, 0xc1, 0xb8, 0x43, 0x59, 0x19, 0xc3, 0x3f, 0x2e, 0xcd, 0xdf,
-	0xf9, 0xe7, 0xfa, 0x9e, 0x7e, 0x0c, 0xc8, 0xb2, 0xbf, 0x40, 0x3a, 0xdf, 0x73, 0xf6, 0x81, 0x36,
-	0xcf, 0x52, 0xea, 0xf8, 0xd6, 0x0d, 0xdf, 0x1a, 0x5d, 0x99, 0xc3, 0xb7, 0xdd, 0x3a, 0x1c, 0x45,
-	0xc1, 0xd1, 0x28, 0x0a, 0x7e, 0x8d, 0xa2, 0xe0, 0xd3, 0x38, 0xaa, 0x1c, 0x8d, 0xa3, 0xca, 0x8f,
-	0x71, 0x54, 0x79, 0xdd, 0x9c, 0x2a, 0xdf, 0x1e, 0x6b, 0x8b, 0x83, 0x4c, 0xe8, 0x6e, 0xd5, 0xfc,
-	0x0a, 0xee, 0xfe, 0x09, 0x00, 0x00, 0xff, 0xff, 0x37, 0x81, 0x80, 0xf8, 0xac, 0x04, 0x00, 0x00,
+	// 713 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0x4f, 0x4f, 0xd4, 0x4e,
+	0x18, 0xc7, 0xb7, 0xfc, 0x60, 0xc9, 0x6f, 0x20, 0x6a, 0x86, 0x4d, 0x76, 0xb7, 0x9a, 0x05, 0x2a,
+	0x08, 0x92, 0x30, 0x05, 0x8c, 0x9a, 0x10, 0x13, 0xc3, 0x9a, 0x60, 0x8c, 0x1e, 0x70, 0x8d, 0x1e,
+	0x4c, 0xcc, 0x66, 0x0a, 0x43, 0x6d, 0x2c, 0x33, 0x65, 0xa7, 0x25, 0x36, 0xc6, 0x8b, 0x89, 0x57,
+	0x63, 0xe2, 0xc5, 0x78, 0xd6, 0xb3, 0x17, 0x5f, 0x04, 0xf1, 0x44, 0xe2, 0xc5, 0x13, 0x31, 0xe0,
+	0x2b, 0xf0, 0x15, 0x98, 0x9d, 0x79, 0xba, 0xb4, 0x74, 0x61, 0x21, 0x72, 0x6b, 0x9f, 0x79, 0xfe,
+	0x7c, 0x9e, 0xe7, 0x3b, 0xf3, 0xa0, 0x92, 0xf4, 0xa9, 0x7c, 0xee, 0x71, 0xd7, 0xde, 0x8c, 0x58,
+	0x2b, 0x26, 0x41, 0x4b, 0x84, 0x02, 0x9f, 0x77, 0x19, 0x0d, 0x04, 0x25, 0xc9, 0xa1, 0x39, 0xb3,
+	0x2a, 0xe4, 0x86, 0x90, 0xb6, 0x43, 0x25, 0xd3, 0x9e, 0xf6, 0xd6, 0xbc, 0xc3, 0x42, 0x3a, 0x6f,
+	0x07, 0xd4, 0xf5, 0x38, 0x0d, 0x3d, 0xc1, 0x75, 0xb0, 0x59, 0xd5, 0xbe, 0x4d, 0xf5, 0x67, 0xeb,
+	0x1f, 0x38, 0x2a, 0xb9, 0xc2, 0x15, 0xda, 0xde, 0xfe, 0x02, 0xeb, 0x25, 0x57, 0x08, 0xd7, 0x67,
+	0x36, 0x0d, 0x3c, 0x9b, 0x72, 0x2e, 0x42, 0x95, 0x2d, 0x89, 0xa9, 0xc2, 0xa9, 0xfa, 0x73, 0xa2,
+	0x75, 0x9b, 0x72, 0xc0, 0x34, 0xcb, 0x1d, 0xf8, 0xe4, 0x43, 0x1f, 0x58, 0x25, 0x84, 0x1f, 0xb6,
+	0x21, 0x57, 0x68, 0x8b, 0x6e, 0xc8, 0x06, 0xdb, 0x8c, 0x98, 0x0c, 0xad, 0x07, 0x68, 0x24, 0x63,
+	0x95, 0x81, 0xe0, 0x92, 0xe1, 0xeb, 0xa8, 0x18, 0x28, 0x4b, 0xc5, 0x18, 0x33, 0xa6, 0x87, 0x16,
+	0xca, 0xe4, 0x50, 0xf7, 0x44, 0x07, 0xd4, 0xfb, 0xb7, 0x77, 0x47, 0x0b, 0x0d, 0x70, 0xb6, 0x6e,
+	0xa1, 0xb2, 0xca, 0xf6, 0xc8, 0x73, 0xb9, 0xc7, 0xdd, 0x7b, 0x7c, 0x5d, 0x40, 0x21, 0x3c, 0x8e,
+	0x86, 0x57, 0x05, 0x97, 0x4d, 0xba, 0xb6, 0xd6, 0x62, 0x52, 0xe7, 0xfd, 0xbf, 0x31, 0xd4, 0xb6,
+	0x2d, 0x69, 0x93, 0xb5, 0x89, 0x2a, 0xf9, 0x68, 0x00, 0x7a, 0x8c, 0x2e, 0x6c, 0x51, 0xbf, 0x29,
+	0xf5, 0x51, 0xd3, 0xe3, 0xeb, 0x02, 0xd0, 0x26, 0x73, 0x68, 0x4f, 0xa8, 0xef, 0xad, 0xd1, 0x50,
+	0xb4, 0x52, 0x89, 0x00, 0xf4, 0xdc, 0x16, 0xf5, 0x53, 0x56, 0xcb, 0xc9, 0x97, 0x4c, 0x46, 0x83,
+	0x97, 0x11, 0x3a, 0xd0, 0x11, 0x8a, 0x5d, 0x21, 0xa0, 0x5d, 0x5b, 0x74, 0xa2, 0xaf, 0x07, 0x88,
+	0x4e, 0x56, 0xa8, 0xcb, 0x20, 0xb6, 0x91, 0x8a, 0xb4, 0xbe, 0x18, 0xa8, 0xda, 0xa5, 0x08, 0x34,
+	0x76, 0x1b, 0xf5, 0x43, 0x33, 0xff, 0x9d, 0xb6, 0x19, 0x15, 0x88, 0xef, 0x66, 0x30, 0xfb, 0x14,
+	0xe6, 0x54, 0x4f, 0x4c, 0x5d, 0x3d, 0xc3, 0x79, 0x33, 0xb9, 0x20, 0x91, 0xf3, 0x82, 0xc5, 0xa7,
+	0xd0, 0xed, 0x19, 0x1a, 0xc9, 0x04, 0x42, 0x67, 0xcb, 0xa8, 0x18, 0x28, 0x0b, 0xcc, 0xae, 0x44,
+	0xf4, 0xad, 0x25, 0xc9, 0xad, 0x25, 0x4b, 0x3c, 0xae, 0x57, 0xbe, 0x7f, 0x9b, 0x2d, 0x01, 0xed,
+	0x6a, 0x2b, 0x0e, 0x42, 0x41, 0x56, 0x22, 0xe7, 0x3e, 0x8b, 0x1b, 0x10, 0x7d, 0x28, 0xfd, 0x99,
+	0xcb, 0xf3, 0xd9, 0x40, 0xa5, 0x6c, 0x7e, 0xe0, 0xbf, 0x83, 0x06, 0x35, 0x81, 0x04, 0x71, 0x2e,
+	0xe7, 0xc4, 0x69, 0x4f, 0x20, 0xe9, 0xda, 0x57, 0xe9, 0x40, 0x9a, 0x24, 0xf2, 0xec, 0xd4, 0xf9,
+	0x68, 0x20, 0x9c, 0x2f, 0x87, 0x17, 0xbb, 0xc9, 0x53, 0x2f, 0xff, 0xd9, 0x1d, 0x1d, 0x89, 0xe9,
+	0x86, 0xbf, 0x68, 0xa5, 0x4f, 0xad, 0x8c, 0x6e, 0x29, 0x81, 0xfa, 0xfe, 0x45, 0xa0, 0x85, 0xaf,
+	0x03, 0x68, 0x40, 0x4d, 0x10, 0x47, 0xa8, 0xa8, 0xf7, 0x02, 0xce, 0xcf, 0x2a, 0xbf, 0x7c, 0xcc,
+	0x89, 0xe3, 0x9d, 0xf4, 0x14, 0xac, 0xb1, 0x37, 0x3f, 0x7e, 0x7f, 0xe8, 0x33, 0x71, 0xc5, 0x76,
+	0x19, 0x9d, 0x0d, 0x04, 0xed, 0x6c, 0x36, 0x5b, 0xaf, 0x1d, 0xfc, 0xc9, 0x40, 0x43, 0xa9, 0xe7,
+	0x81, 0xa7, 0xbb, 0xe7, 0xcd, 0x6f, 0x25, 0xf3, 0xea, 0x09, 0x3c, 0x01, 0xe3, 0x86, 0xc2, 0x98,
+	0xc3, 0x24, 0x8f, 0x91, 0xde, 0x4a, 0xd2, 0x7e, 0x95, 0x1e, 0xf9, 0x6b, 0xfc, 0xce, 0x40, 0xc3,
+	0xe9, 0x97, 0x8f, 0x7b, 0xd7, 0xec, 0x0c, 0x68, 0xe6, 0x24, 0xae, 0xc0, 0x37, 0xa5, 0xf8, 0xc6,
+	0xf1, 0x68, 0x0f, 0x3e, 0xfc, 0xd6, 0x40, 0x45, 0x7d, 0x8b, 0x8e, 0x54, 0x29, 0xbd, 0x01, 0xcc,
+	0x89, 0xe3, 0x9d, 0xa0, 0xfc, 0x9c, 0x2a, 0x3f, 0x83, 0xa7, 0xbb, 0xa8, 0xa4, 0x3c, 0x73, 0x83,
+	0x89, 0xd1, 0x20, 0x3c, 0x39, 0x7c, 0x6c, 0x89, 0xce, 0x34, 0x26, 0x7b, 0x78, 0x01, 0xc9, 0xb8,
+	0x22, 0xb9, 0x88, 0xab, 0x47, 0x92, 0xd4, 0x17, 0xb6, 0xf7, 0x6a, 0xc6, 0xce, 0x5e, 0xcd, 0xf8,
+	0xb5, 0x57, 0x33, 0xde, 0xef, 0xd7, 0x0a, 0x3b, 0xfb, 0xb5, 0xc2, 0xcf, 0xfd, 0x5a, 0xe1, 0x69,
+	0x25, 0x89, 0x79, 0x79, 0x10, 0x15, 0xc6, 0x01, 0x93, 0x4e, 0x51, 0xbd, 0x8a, 0x6b, 0x7f, 0x07,
+	0x00, 0xda, 0x5e, 0x6b, 0xe9, 0x1e, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -372,6 +636,10 @@ type QueryClient interface {
 	SigningInfo(ctx context.Context, in *QuerySigningInfoRequest, opts ...grpc.CallOption) (*QuerySigningInfoResponse, error)
 	// SigningInfos queries signing info of all validators
 	SigningInfos(ctx context.Context, in *QuerySigningInfosRequest, opts ...grpc.CallOption) (*QuerySigningInfosResponse, error)
+	// Pubkey queries the address-pubkey relation of given cons address
+	Pubkey(ctx context.Context, in *QueryPubkeyRequest, opts ...grpc.CallOption) (*QueryPubkeyResponse, error)
+	// Pubkeys queries the address-pubkey relations of all validators
+	Pubkeys(ctx context.Context, in *QueryPubkeysRequest, opts ...grpc.CallOption) (*QueryPubkeysResponse, error)
 }
 
 type queryClient struct {
@@ -409,6 +677,24 @@ func (c *queryClient) SigningInfos(ctx context.Context, in *QuerySigningInfosReq
 	return out, nil
 }
 
+func (c *queryClient) Pubkey(ctx context.Context, in *QueryPubkeyRequest, opts ...grpc.CallOption) (*QueryPubkeyResponse, error) {
+	out := new(QueryPubkeyResponse)
+	err := c.cc.Invoke(ctx, "/geapoa.slashing.Query/Pubkey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) Pubkeys(ctx context.Context, in *QueryPubkeysRequest, opts ...grpc.CallOption) (*QueryPubkeysResponse, error) {
+	out := new(QueryPubkeysResponse)
+	err := c.cc.Invoke(ctx, "/geapoa.slashing.Query/Pubkeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// Params queries the parameters of slashing module
@@ -417,6 +703,10 @@ type QueryServer interface {
 	SigningInfo(context.Context, *QuerySigningInfoRequest) (*QuerySigningInfoResponse, error)
 	// SigningInfos queries signing info of all validators
 	SigningInfos(context.Context, *QuerySigningInfosRequest) (*QuerySigningInfosResponse, error)
+	// Pubkey queries the address-pubkey relation of given cons address
+	Pubkey(context.Context, *QueryPubkeyRequest) (*QueryPubkeyResponse, error)
+	// Pubkeys queries the address-pubkey relations of all validators
+	Pubkeys(context.Context, *QueryPubkeysRequest) (*QueryPubkeysResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -432,6 +722,12 @@ func (*UnimplementedQueryServer) SigningInfo(ctx context.Context, req *QuerySign
 func (*UnimplementedQueryServer) SigningInfos(ctx context.Context, req *QuerySigningInfosRequest) (*QuerySigningInfosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SigningInfos not implemented")
 }
+func (*UnimplementedQueryServer) Pubkey(ctx context.Context, req *QueryPubkeyRequest) (*QueryPubkeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pubkey not implemented")
+}
+func (*UnimplementedQueryServer) Pubkeys(ctx context.Context, req *QueryPubkeysRequest) (*QueryPubkeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pubkeys not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -491,6 +787,42 @@ func _Query_SigningInfos_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_Pubkey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPubkeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Pubkey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geapoa.slashing.Query/Pubkey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Pubkey(ctx, req.(*QueryPubkeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_Pubkeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryPubkeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).Pubkeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/geapoa.slashing.Query/Pubkeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).Pubkeys(ctx, req.(*QueryPubkeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "geapoa.slashing.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -507,6 +839,14 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SigningInfos",
 			Handler:    _Query_SigningInfos_Handler,
 		},
+		{
+			MethodName: "Pubkey",
+			Handler:    _Query_Pubkey_Handler,
+		},
+		{
+			MethodName: "Pubkeys",
+			Handler:    _Query_Pubkeys_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "slashing/query.proto",
@@ -715,62 +1055,253 @@ func (m *QuerySigningInfosResponse) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
-	offset -= sovQuery(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func (m *QueryParamsRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryPubkeyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *QueryParamsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Params.Size()
-	n += 1 + l + sovQuery(uint64(l))
-	return n
+func (m *QueryPubkeyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *QuerySigningInfoRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *QueryPubkeyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.ConsAddress)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
+	if len(m.ConsAddress) > 0 {
+		i -= len(m.ConsAddress)
+		copy(dAtA[i:], m.ConsAddress)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.ConsAddress)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *QuerySigningInfoResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *QueryPubkeyResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.ValSigningInfo.Size()
-	n += 1 + l + sovQuery(uint64(l))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *QuerySigningInfosRequest) Size() (n int) {
+func (m *QueryPubkeyResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryPubkeyResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pubkey != nil {
+		{
+			size, err := m.Pubkey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryPubkeysRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryPubkeysRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryPubkeysRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pagination != nil {
+		{
+			size, err := m.Pagination.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryPubkeysResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryPubkeysResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryPubkeysResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pagination != nil {
+		{
+			size, err := m.Pagination.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Pubkeys) > 0 {
+		for iNdEx := len(m.Pubkeys) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Pubkeys[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddrPubkeyRelation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddrPubkeyRelation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddrPubkeyRelation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Pubkey != nil {
+		{
+			size, err := m.Pubkey.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ConsAddress) > 0 {
+		i -= len(m.ConsAddress)
+		copy(dAtA[i:], m.ConsAddress)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.ConsAddress)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryParamsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *QueryParamsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Params.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QuerySigningInfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ConsAddress)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QuerySigningInfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ValSigningInfo.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *QuerySigningInfosRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -795,20 +1326,393 @@ func (m *QuerySigningInfosResponse) Size() (n int) {
 			n += 1 + l + sovQuery(uint64(l))
 		}
 	}
-	if m.Pagination != nil {
-		l = m.Pagination.Size()
-		n += 1 + l + sovQuery(uint64(l))
-	}
-	return n
-}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryPubkeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ConsAddress)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryPubkeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pubkey != nil {
+		l = m.Pubkey.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryPubkeysRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryPubkeysResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Pubkeys) > 0 {
+		for _, e := range m.Pubkeys {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if m.Pagination != nil {
+		l = m.Pagination.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *AddrPubkeyRelation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ConsAddress)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Pubkey != nil {
+		l = m.Pubkey.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *QueryParamsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryParamsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryParamsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryParamsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryParamsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuerySigningInfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuerySigningInfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuerySigningInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConsAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuerySigningInfoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuerySigningInfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuerySigningInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValSigningInfo", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ValSigningInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
 
-func sovQuery(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozQuery(x uint64) (n int) {
-	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *QueryParamsRequest) Unmarshal(dAtA []byte) error {
+func (m *QuerySigningInfosRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -831,12 +1735,48 @@ func (m *QueryParamsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryParamsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: QuerySigningInfosRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryParamsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QuerySigningInfosRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pagination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pagination == nil {
+				m.Pagination = &query.PageRequest{}
+			}
+			if err := m.Pagination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -858,7 +1798,7 @@ func (m *QueryParamsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
+func (m *QuerySigningInfosResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -881,15 +1821,15 @@ func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QueryParamsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: QuerySigningInfosResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QueryParamsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QuerySigningInfosResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Params", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -916,7 +1856,44 @@ func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Params.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Info = append(m.Info, ValidatorSigningInfo{})
+			if err := m.Info[len(m.Info)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pagination", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pagination == nil {
+				m.Pagination = &query.PageResponse{}
+			}
+			if err := m.Pagination.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -941,7 +1918,7 @@ func (m *QueryParamsResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QuerySigningInfoRequest) Unmarshal(dAtA []byte) error {
+func (m *QueryPubkeyRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -964,10 +1941,10 @@ func (m *QuerySigningInfoRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QuerySigningInfoRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryPubkeyRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QuerySigningInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryPubkeyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1023,7 +2000,7 @@ func (m *QuerySigningInfoRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QuerySigningInfoResponse) Unmarshal(dAtA []byte) error {
+func (m *QueryPubkeyResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1046,15 +2023,15 @@ func (m *QuerySigningInfoResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QuerySigningInfoResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryPubkeyResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QuerySigningInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryPubkeyResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValSigningInfo", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubkey", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1081,7 +2058,10 @@ func (m *QuerySigningInfoResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.ValSigningInfo.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Pubkey == nil {
+				m.Pubkey = &types.Any{}
+			}
+			if err := m.Pubkey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1106,7 +2086,7 @@ func (m *QuerySigningInfoResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QuerySigningInfosRequest) Unmarshal(dAtA []byte) error {
+func (m *QueryPubkeysRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1129,10 +2109,10 @@ func (m *QuerySigningInfosRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QuerySigningInfosRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryPubkeysRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QuerySigningInfosRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryPubkeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1192,7 +2172,7 @@ func (m *QuerySigningInfosRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *QuerySigningInfosResponse) Unmarshal(dAtA []byte) error {
+func (m *QueryPubkeysResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1215,15 +2195,15 @@ func (m *QuerySigningInfosResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: QuerySigningInfosResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: QueryPubkeysResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: QuerySigningInfosResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QueryPubkeysResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Info", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubkeys", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1250,8 +2230,8 @@ func (m *QuerySigningInfosResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Info = append(m.Info, ValidatorSigningInfo{})
-			if err := m.Info[len(m.Info)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Pubkeys = append(m.Pubkeys, AddrPubkeyRelation{})
+			if err := m.Pubkeys[len(m.Pubkeys)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1312,6 +2292,124 @@ func (m *QuerySigningInfosResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AddrPubkeyRelation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddrPubkeyRelation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddrPubkeyRelation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConsAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pubkey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Pubkey == nil {
+				m.Pubkey = &types.Any{}
+			}
+			if err := m.Pubkey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -44,3 +45,74 @@ func UnmarshalValSigningInfo(cdc codec.Codec, value []byte) (signingInfo Validat
 	err = cdc.Unmarshal(value, &signingInfo)
 	return signingInfo, err
 }
+
+// ValidatorStart pairs a validator with the height its signing window started.
+type ValidatorStart struct {
+	ConsAddress sdk.ConsAddress
+	StartHeight int64
+}
+
+// ValidatorMiss pairs a validator with its missed blocks counter. Tombstoned
+// validators are flagged.
+type ValidatorMiss struct {
+	ConsAddress  sdk.ConsAddress
+	MissedBlocks int64
+	Tombstoned   bool
+}
+
+// Reasons a validator's signing info is reported out of sync with srstaking.
+const (
+	DesyncMissingSigningInfo = "missing signing info"
+	DesyncAddressMismatch    = "signing info address mismatch"
+	DesyncTombstonedUnjailed = "tombstoned but not jailed"
+)
+
+// DesyncReport is a validator whose signing info disagrees with its srstaking
+// state, along with one of the Desync reasons.
+type DesyncReport struct {
+	ConsAddress sdk.ConsAddress
+	Reason      string
+}
+
+// JailEvent is a jailing of a validator, as indexed by height.
+type JailEvent struct {
+	ConsAddress sdk.ConsAddress
+	Height      int64
+}
+
+// SigningInfoSnapshot is the signing info of every validator captured at a
+// height.
+type SigningInfoSnapshot struct {
+	Height int64
+	Infos  []SigningInfo
+}
+
+// SigningInfoDelta is the change of a validator's missed blocks counter
+// between two snapshots.
+type SigningInfoDelta struct {
+	Address           string
+	MissedBlocksDelta int64
+}
+
+// CompareSigningSnapshots returns the missed blocks counter delta from a to b
+// of every validator present in either snapshot, sorted by address. A
+// validator missing from a snapshot counts as 0 missed blocks there. Deltas
+// can be negative since the counter resets on downtime jailing and as signed
+// blocks slide into the window.
+func CompareSigningSnapshots(a, b SigningInfoSnapshot) []SigningInfoDelta {
+	missed := map[string]int64{}
+	for _, info := range a.Infos {
+		missed[info.Address] -= info.ValidatorSigningInfo.MissedBlocksCounter
+	}
+	for _, info := range b.Infos {
+		missed[info.Address] += info.ValidatorSigningInfo.MissedBlocksCounter
+	}
+
+	deltas := make([]SigningInfoDelta, 0, len(missed))
+	for address, delta := range missed {
+		deltas = append(deltas, SigningInfoDelta{Address: address, MissedBlocksDelta: delta})
+	}
+
+	sort.Slice(deltas, func(i, j int) bool { return deltas[i].Address < deltas[j].Address })
+	return deltas
+}
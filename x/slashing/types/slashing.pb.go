@@ -29,6 +29,38 @@ var _ = time.Kitchen
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// Infraction defines the kind of misbehaviour a validator is slashed for.
+type Infraction int32
+
+const (
+	// INFRACTION_UNSPECIFIED defines an unspecified infraction.
+	InfractionUnspecified Infraction = 0
+	// INFRACTION_DOUBLE_SIGN defines a validator that double-signs a block.
+	InfractionDoubleSign Infraction = 1
+	// INFRACTION_DOWNTIME defines a validator that missed signing too many blocks.
+	InfractionDowntime Infraction = 2
+)
+
+var Infraction_name = map[int32]string{
+	0: "INFRACTION_UNSPECIFIED",
+	1: "INFRACTION_DOUBLE_SIGN",
+	2: "INFRACTION_DOWNTIME",
+}
+
+var Infraction_value = map[string]int32{
+	"INFRACTION_UNSPECIFIED": 0,
+	"INFRACTION_DOUBLE_SIGN": 1,
+	"INFRACTION_DOWNTIME":    2,
+}
+
+func (x Infraction) String() string {
+	return proto.EnumName(Infraction_name, int32(x))
+}
+
+func (Infraction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b24ff443e5dfee94, []int{0}
+}
+
 // ValidatorSigningInfo defines a validator's signing info for monitoring their
 // liveness activity.
 type ValidatorSigningInfo struct {
@@ -125,9 +157,17 @@ func (m *ValidatorSigningInfo) GetMissedBlocksCounter() int64 {
 
 // Params represents the parameters used for by the slashing module.
 type Params struct {
-	SignedBlocksWindow   int64                                  `protobuf:"varint,1,opt,name=signed_blocks_window,json=signedBlocksWindow,proto3" json:"signed_blocks_window,omitempty" yaml:"signed_blocks_window"`
-	MinSignedPerWindow   github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,2,opt,name=min_signed_per_window,json=minSignedPerWindow,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"min_signed_per_window" yaml:"min_signed_per_window"`
-	DowntimeJailDuration time.Duration                          `protobuf:"bytes,3,opt,name=downtime_jail_duration,json=downtimeJailDuration,proto3,stdduration" json:"downtime_jail_duration" yaml:"downtime_jail_duration"`
+	SignedBlocksWindow      int64                                  `protobuf:"varint,1,opt,name=signed_blocks_window,json=signedBlocksWindow,proto3" json:"signed_blocks_window,omitempty" yaml:"signed_blocks_window"`
+	MinSignedPerWindow      github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,2,opt,name=min_signed_per_window,json=minSignedPerWindow,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"min_signed_per_window" yaml:"min_signed_per_window"`
+	DowntimeJailDuration    time.Duration                          `protobuf:"bytes,3,opt,name=downtime_jail_duration,json=downtimeJailDuration,proto3,stdduration" json:"downtime_jail_duration" yaml:"downtime_jail_duration"`
+	SlashFractionDoubleSign github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,4,opt,name=slash_fraction_double_sign,json=slashFractionDoubleSign,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_double_sign" yaml:"slash_fraction_double_sign"`
+	SlashFractionDowntime   github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,5,opt,name=slash_fraction_downtime,json=slashFractionDowntime,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"slash_fraction_downtime" yaml:"slash_fraction_downtime"`
+	// Number of blocks a validator must wait after unjailing before it can
+	// unjail again, 0 disables the cooldown.
+	UnjailCooldownBlocks int64 `protobuf:"varint,6,opt,name=unjail_cooldown_blocks,json=unjailCooldownBlocks,proto3" json:"unjail_cooldown_blocks,omitempty" yaml:"unjail_cooldown_blocks"`
+	// Time a validator can stay jailed past its jailed until time before it is
+	// eligible for removal, 0 disables the removal.
+	MaxJailDuration time.Duration `protobuf:"bytes,7,opt,name=max_jail_duration,json=maxJailDuration,proto3,stdduration" json:"max_jail_duration" yaml:"max_jail_duration"`
 }
 
 func (m *Params) Reset()         { *m = Params{} }
@@ -177,50 +217,247 @@ func (m *Params) GetDowntimeJailDuration() time.Duration {
 	return 0
 }
 
+func (m *Params) GetUnjailCooldownBlocks() int64 {
+	if m != nil {
+		return m.UnjailCooldownBlocks
+	}
+	return 0
+}
+
+func (m *Params) GetMaxJailDuration() time.Duration {
+	if m != nil {
+		return m.MaxJailDuration
+	}
+	return 0
+}
+
+// SlashRecord records a slash applied to a validator for audit purposes.
+type SlashRecord struct {
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Height at which the validator was slashed
+	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Height of the stake distribution the slash was applied to
+	DistributionHeight int64                                  `protobuf:"varint,3,opt,name=distribution_height,json=distributionHeight,proto3" json:"distribution_height,omitempty" yaml:"distribution_height"`
+	Reason             Infraction                             `protobuf:"varint,4,opt,name=reason,proto3,enum=geapoa.slashing.Infraction" json:"reason,omitempty"`
+	Fraction           github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,5,opt,name=fraction,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"fraction"`
+	Power              int64                                  `protobuf:"varint,6,opt,name=power,proto3" json:"power,omitempty"`
+	// Timestamp of the block the validator was slashed at
+	Time time.Time `protobuf:"bytes,7,opt,name=time,proto3,stdtime" json:"time"`
+}
+
+func (m *SlashRecord) Reset()         { *m = SlashRecord{} }
+func (m *SlashRecord) String() string { return proto.CompactTextString(m) }
+func (*SlashRecord) ProtoMessage()    {}
+func (*SlashRecord) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b24ff443e5dfee94, []int{2}
+}
+func (m *SlashRecord) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SlashRecord) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SlashRecord.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SlashRecord) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SlashRecord.Merge(m, src)
+}
+func (m *SlashRecord) XXX_Size() int {
+	return m.Size()
+}
+func (m *SlashRecord) XXX_DiscardUnknown() {
+	xxx_messageInfo_SlashRecord.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SlashRecord proto.InternalMessageInfo
+
+func (m *SlashRecord) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *SlashRecord) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SlashRecord) GetDistributionHeight() int64 {
+	if m != nil {
+		return m.DistributionHeight
+	}
+	return 0
+}
+
+func (m *SlashRecord) GetReason() Infraction {
+	if m != nil {
+		return m.Reason
+	}
+	return InfractionUnspecified
+}
+
+func (m *SlashRecord) GetPower() int64 {
+	if m != nil {
+		return m.Power
+	}
+	return 0
+}
+
+func (m *SlashRecord) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
+// PenaltySummary holds the lifetime penalty counters of a validator.
+type PenaltySummary struct {
+	// Number of times the validator was jailed for downtime
+	DowntimeJails int64 `protobuf:"varint,1,opt,name=downtime_jails,json=downtimeJails,proto3" json:"downtime_jails,omitempty" yaml:"downtime_jails"`
+	// Number of times the validator was tombstoned
+	Tombstones int64 `protobuf:"varint,2,opt,name=tombstones,proto3" json:"tombstones,omitempty"`
+	// Number of blocks the validator missed
+	MissedBlocks int64 `protobuf:"varint,3,opt,name=missed_blocks,json=missedBlocks,proto3" json:"missed_blocks,omitempty" yaml:"missed_blocks"`
+}
+
+func (m *PenaltySummary) Reset()         { *m = PenaltySummary{} }
+func (m *PenaltySummary) String() string { return proto.CompactTextString(m) }
+func (*PenaltySummary) ProtoMessage()    {}
+func (*PenaltySummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b24ff443e5dfee94, []int{3}
+}
+func (m *PenaltySummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PenaltySummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PenaltySummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PenaltySummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PenaltySummary.Merge(m, src)
+}
+func (m *PenaltySummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *PenaltySummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_PenaltySummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PenaltySummary proto.InternalMessageInfo
+
+func (m *PenaltySummary) GetDowntimeJails() int64 {
+	if m != nil {
+		return m.DowntimeJails
+	}
+	return 0
+}
+
+func (m *PenaltySummary) GetTombstones() int64 {
+	if m != nil {
+		return m.Tombstones
+	}
+	return 0
+}
+
+func (m *PenaltySummary) GetMissedBlocks() int64 {
+	if m != nil {
+		return m.MissedBlocks
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("geapoa.slashing.Infraction", Infraction_name, Infraction_value)
 	proto.RegisterType((*ValidatorSigningInfo)(nil), "geapoa.slashing.ValidatorSigningInfo")
 	proto.RegisterType((*Params)(nil), "geapoa.slashing.Params")
+	proto.RegisterType((*SlashRecord)(nil), "geapoa.slashing.SlashRecord")
+	proto.RegisterType((*PenaltySummary)(nil), "geapoa.slashing.PenaltySummary")
 }
 
 func init() { proto.RegisterFile("slashing/slashing.proto", fileDescriptor_b24ff443e5dfee94) }
 
 var fileDescriptor_b24ff443e5dfee94 = []byte{
-	// 556 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0x31, 0x6f, 0xd3, 0x40,
-	0x18, 0xcd, 0x35, 0x50, 0x8a, 0x13, 0x09, 0xc9, 0x0d, 0xd4, 0x04, 0xf0, 0x45, 0x1e, 0x50, 0x18,
-	0x6a, 0x4b, 0x45, 0x62, 0xc8, 0x68, 0x3a, 0x00, 0x03, 0x14, 0xb7, 0x80, 0xc4, 0x80, 0x75, 0xc9,
-	0x5d, 0x2e, 0x47, 0xed, 0x3b, 0xcb, 0x77, 0x51, 0x5a, 0x36, 0x36, 0xc6, 0x8e, 0x1d, 0x3b, 0xf2,
-	0x53, 0x3a, 0x76, 0x44, 0x20, 0x05, 0x94, 0x2c, 0xcc, 0xf9, 0x05, 0xc8, 0x77, 0x76, 0x1b, 0x95,
-	0x4c, 0xbe, 0xf7, 0xde, 0xf7, 0xbd, 0xbb, 0x77, 0xdf, 0xd9, 0xda, 0x92, 0x09, 0x92, 0x23, 0xc6,
-	0x69, 0x50, 0x2d, 0xfc, 0x2c, 0x17, 0x4a, 0xd8, 0x77, 0x28, 0x41, 0x99, 0x40, 0x7e, 0x45, 0xb7,
-	0x5b, 0x54, 0x50, 0xa1, 0xb5, 0xa0, 0x58, 0x99, 0xb2, 0xb6, 0x4b, 0x85, 0xa0, 0x09, 0x09, 0x34,
-	0xea, 0x8f, 0x87, 0x01, 0x1e, 0xe7, 0x48, 0x31, 0xc1, 0x4b, 0x1d, 0x5e, 0xd7, 0x15, 0x4b, 0x89,
-	0x54, 0x28, 0xcd, 0x4c, 0x81, 0xf7, 0xad, 0x6e, 0xb5, 0xde, 0xa3, 0x84, 0x61, 0xa4, 0x44, 0xbe,
-	0xcf, 0x28, 0x67, 0x9c, 0xbe, 0xe4, 0x43, 0x61, 0x3b, 0xd6, 0x2d, 0x84, 0x71, 0x4e, 0xa4, 0x74,
-	0x40, 0x07, 0x74, 0x6f, 0x47, 0x15, 0xb4, 0x7b, 0x56, 0x53, 0x2a, 0x94, 0xab, 0x78, 0x44, 0x18,
-	0x1d, 0x29, 0x67, 0xad, 0x03, 0xba, 0xf5, 0x70, 0x6b, 0x31, 0x85, 0x9b, 0xc7, 0x28, 0x4d, 0x7a,
-	0xde, 0xb2, 0xea, 0x45, 0x0d, 0x0d, 0x5f, 0x68, 0x54, 0xf4, 0x32, 0x8e, 0xc9, 0x51, 0x2c, 0x86,
-	0x43, 0x49, 0x94, 0x53, 0xbf, 0xde, 0xbb, 0xac, 0x7a, 0x51, 0x43, 0xc3, 0x37, 0x1a, 0xd9, 0x9f,
-	0xac, 0xe6, 0x67, 0xc4, 0x12, 0x82, 0xe3, 0x31, 0x57, 0x2c, 0x71, 0x6e, 0x74, 0x40, 0xb7, 0xb1,
-	0xd3, 0xf6, 0x4d, 0x44, 0xbf, 0x8a, 0xe8, 0x1f, 0x54, 0x11, 0x43, 0x78, 0x3e, 0x85, 0xb5, 0x2b,
-	0xef, 0xe5, 0x6e, 0xef, 0xe4, 0x37, 0x04, 0x51, 0xc3, 0x50, 0xef, 0x0a, 0xc6, 0x76, 0x2d, 0x4b,
-	0x89, 0xb4, 0x2f, 0x95, 0xe0, 0x04, 0x3b, 0x37, 0x3b, 0xa0, 0xbb, 0x11, 0x2d, 0x31, 0xf6, 0x81,
-	0x75, 0x37, 0x65, 0x52, 0x12, 0x1c, 0xf7, 0x13, 0x31, 0x38, 0x94, 0xf1, 0x40, 0x8c, 0xb9, 0x22,
-	0xb9, 0xb3, 0xae, 0x43, 0x74, 0x16, 0x53, 0xf8, 0xd0, 0x6c, 0xb4, 0xb2, 0xcc, 0x8b, 0x36, 0x0d,
-	0x1f, 0x6a, 0xfa, 0xb9, 0x61, 0x7b, 0x1b, 0xa7, 0x67, 0xb0, 0xf6, 0xf7, 0x0c, 0x02, 0xef, 0xd7,
-	0x9a, 0xb5, 0xbe, 0x87, 0x72, 0x94, 0x4a, 0xfb, 0xad, 0xd5, 0x92, 0x8c, 0xf2, 0x2b, 0x8f, 0x09,
-	0xe3, 0x58, 0x4c, 0xf4, 0x24, 0xea, 0x21, 0x5c, 0x4c, 0xe1, 0x83, 0xf2, 0xaa, 0x57, 0x54, 0x79,
-	0x91, 0x6d, 0x68, 0xb3, 0xd1, 0x07, 0x4d, 0xda, 0x5f, 0x41, 0x71, 0x7c, 0x1e, 0x97, 0x1d, 0x19,
-	0xc9, 0x2b, 0xd3, 0x62, 0x7e, 0xcd, 0xf0, 0x75, 0x71, 0x57, 0x3f, 0xa7, 0xf0, 0x31, 0x65, 0x6a,
-	0x34, 0xee, 0xfb, 0x03, 0x91, 0x06, 0x03, 0x21, 0x53, 0x21, 0xcb, 0xcf, 0xb6, 0xc4, 0x87, 0x81,
-	0x3a, 0xce, 0x88, 0xf4, 0x77, 0xc9, 0x60, 0x39, 0xec, 0x0a, 0x53, 0x2f, 0xb2, 0x53, 0xc6, 0xf7,
-	0x35, 0xbd, 0x47, 0xf2, 0xf2, 0x0c, 0x5f, 0xac, 0x7b, 0x58, 0x4c, 0x78, 0xf1, 0x06, 0xe3, 0xe2,
-	0xe6, 0xe3, 0xea, 0xb5, 0xea, 0x77, 0xd0, 0xd8, 0xb9, 0xff, 0xdf, 0x2c, 0x77, 0xcb, 0x82, 0xf0,
-	0x49, 0x39, 0xca, 0x47, 0x66, 0xd3, 0xd5, 0x36, 0xde, 0x69, 0x31, 0xd4, 0x56, 0x25, 0xbe, 0x42,
-	0x2c, 0xb9, 0x34, 0x78, 0xf6, 0x7d, 0xe6, 0x82, 0xf3, 0x99, 0x0b, 0x2e, 0x66, 0x2e, 0xf8, 0x33,
-	0x73, 0xc1, 0xc9, 0xdc, 0xad, 0x5d, 0xcc, 0xdd, 0xda, 0x8f, 0xb9, 0x5b, 0xfb, 0xe8, 0x50, 0x82,
-	0xb6, 0x33, 0x81, 0x82, 0xa3, 0xcb, 0x1f, 0xd1, 0x64, 0xed, 0xaf, 0xeb, 0xb3, 0x3c, 0xfd, 0x17,
-	0x00, 0x00, 0xff, 0xff, 0x81, 0x4d, 0x9c, 0x61, 0xaa, 0x03, 0x00, 0x00,
+	// 988 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcf, 0x6f, 0xe3, 0xc4,
+	0x17, 0x8f, 0xdb, 0x6e, 0xda, 0xef, 0x24, 0xdb, 0xed, 0x77, 0x9a, 0xb6, 0x6e, 0x16, 0xec, 0xac,
+	0x85, 0x50, 0x41, 0x5a, 0x47, 0xea, 0x02, 0x42, 0x95, 0x90, 0xc0, 0xfd, 0x01, 0x59, 0x41, 0x5a,
+	0x26, 0x2d, 0x95, 0x38, 0x60, 0x4d, 0xe2, 0x89, 0x3b, 0xd4, 0xf6, 0x44, 0x1e, 0x47, 0x6d, 0xb9,
+	0x71, 0xab, 0x7a, 0xea, 0x71, 0x2f, 0x95, 0x56, 0xe2, 0xc2, 0x11, 0xfe, 0x02, 0xae, 0x7b, 0xdc,
+	0x23, 0xe2, 0x10, 0xa0, 0xbd, 0x70, 0xce, 0x5f, 0x80, 0x3c, 0x33, 0xde, 0xb8, 0x6d, 0x96, 0x55,
+	0x4f, 0xf1, 0xfb, 0xbc, 0x1f, 0xf3, 0xe6, 0x7d, 0x3e, 0xf3, 0x14, 0xb0, 0xc4, 0x03, 0xcc, 0x0f,
+	0x68, 0xe4, 0xd7, 0xb3, 0x0f, 0xbb, 0x17, 0xb3, 0x84, 0xc1, 0x07, 0x3e, 0xc1, 0x3d, 0x86, 0xed,
+	0x0c, 0xae, 0x56, 0x7c, 0xe6, 0x33, 0xe1, 0xab, 0xa7, 0x5f, 0x32, 0xac, 0x6a, 0xf8, 0x8c, 0xf9,
+	0x01, 0xa9, 0x0b, 0xab, 0xdd, 0xef, 0xd6, 0xbd, 0x7e, 0x8c, 0x13, 0xca, 0x22, 0xe5, 0x37, 0x6f,
+	0xfa, 0x13, 0x1a, 0x12, 0x9e, 0xe0, 0xb0, 0x27, 0x03, 0xac, 0xd3, 0x49, 0x50, 0xf9, 0x06, 0x07,
+	0xd4, 0xc3, 0x09, 0x8b, 0x5b, 0xd4, 0x8f, 0x68, 0xe4, 0x37, 0xa2, 0x2e, 0x83, 0x3a, 0x98, 0xc6,
+	0x9e, 0x17, 0x13, 0xce, 0x75, 0xad, 0xa6, 0xad, 0xfc, 0x0f, 0x65, 0x26, 0x5c, 0x03, 0x65, 0x9e,
+	0xe0, 0x38, 0x71, 0x0f, 0x08, 0xf5, 0x0f, 0x12, 0x7d, 0xa2, 0xa6, 0xad, 0x4c, 0x3a, 0x4b, 0xc3,
+	0x81, 0x39, 0x7f, 0x82, 0xc3, 0x60, 0xcd, 0xca, 0x7b, 0x2d, 0x54, 0x12, 0xe6, 0x17, 0xc2, 0x4a,
+	0x73, 0x69, 0xe4, 0x91, 0x63, 0x97, 0x75, 0xbb, 0x9c, 0x24, 0xfa, 0xe4, 0xcd, 0xdc, 0xbc, 0xd7,
+	0x42, 0x25, 0x61, 0x6e, 0x0b, 0x0b, 0x7e, 0x07, 0xca, 0xdf, 0x63, 0x1a, 0x10, 0xcf, 0xed, 0x47,
+	0x09, 0x0d, 0xf4, 0xa9, 0x9a, 0xb6, 0x52, 0x5a, 0xad, 0xda, 0xf2, 0x8a, 0x76, 0x76, 0x45, 0x7b,
+	0x37, 0xbb, 0xa2, 0x63, 0xbe, 0x18, 0x98, 0x85, 0x51, 0xed, 0x7c, 0xb6, 0x75, 0xfe, 0xa7, 0xa9,
+	0xa1, 0x92, 0x84, 0xf6, 0x52, 0x04, 0x1a, 0x00, 0x24, 0x2c, 0x6c, 0xf3, 0x84, 0x45, 0xc4, 0xd3,
+	0xef, 0xd5, 0xb4, 0x95, 0x19, 0x94, 0x43, 0xe0, 0x2e, 0x58, 0x08, 0x29, 0xe7, 0xc4, 0x73, 0xdb,
+	0x01, 0xeb, 0x1c, 0x72, 0xb7, 0xc3, 0xfa, 0x51, 0x42, 0x62, 0xbd, 0x28, 0x2e, 0x51, 0x1b, 0x0e,
+	0xcc, 0xb7, 0xe4, 0x41, 0x63, 0xc3, 0x2c, 0x34, 0x2f, 0x71, 0x47, 0xc0, 0xeb, 0x12, 0x5d, 0x9b,
+	0x79, 0xf6, 0xdc, 0x2c, 0xfc, 0xf3, 0xdc, 0xd4, 0xac, 0xdf, 0x8a, 0xa0, 0xb8, 0x83, 0x63, 0x1c,
+	0x72, 0xf8, 0x35, 0xa8, 0x70, 0xea, 0x47, 0xa3, 0x1a, 0x47, 0x34, 0xf2, 0xd8, 0x91, 0x60, 0x62,
+	0xd2, 0x31, 0x87, 0x03, 0xf3, 0xa1, 0x1a, 0xf5, 0x98, 0x28, 0x0b, 0x41, 0x09, 0xcb, 0x83, 0xf6,
+	0x05, 0x08, 0x7f, 0xd4, 0xd2, 0xf6, 0x23, 0x57, 0x65, 0xf4, 0x48, 0x9c, 0x15, 0x4d, 0xf9, 0x2b,
+	0x3b, 0xcd, 0x74, 0x56, 0x7f, 0x0c, 0xcc, 0x77, 0x7d, 0x9a, 0x1c, 0xf4, 0xdb, 0x76, 0x87, 0x85,
+	0xf5, 0x0e, 0xe3, 0x21, 0xe3, 0xea, 0xe7, 0x31, 0xf7, 0x0e, 0xeb, 0xc9, 0x49, 0x8f, 0x70, 0x7b,
+	0x83, 0x74, 0xf2, 0x97, 0x1d, 0x53, 0xd4, 0x42, 0x30, 0xa4, 0x51, 0x4b, 0xc0, 0x3b, 0x24, 0x56,
+	0x3d, 0xfc, 0x00, 0x16, 0x3d, 0x76, 0x14, 0xa5, 0x1a, 0x74, 0xd3, 0xc9, 0xbb, 0x99, 0x5a, 0x85,
+	0x0e, 0x4a, 0xab, 0xcb, 0xb7, 0xb8, 0xdc, 0x50, 0x01, 0xce, 0x7b, 0x8a, 0xca, 0xb7, 0xe5, 0xa1,
+	0xe3, 0xcb, 0x58, 0xcf, 0x52, 0x52, 0x2b, 0x99, 0xf3, 0x29, 0xa6, 0x41, 0x56, 0x00, 0x9e, 0x6b,
+	0xa0, 0x2a, 0x1e, 0x93, 0xdb, 0x8d, 0x71, 0x27, 0x85, 0x5c, 0x8f, 0xf5, 0xdb, 0x01, 0x11, 0xcd,
+	0x0b, 0x31, 0x95, 0x9d, 0xd6, 0x9d, 0x87, 0xf0, 0x48, 0xf1, 0xf0, 0xda, 0xca, 0x16, 0x92, 0x6f,
+	0x7c, 0x4b, 0xf9, 0x36, 0x84, 0x2b, 0x9d, 0x0c, 0x3c, 0xd5, 0xc0, 0xd2, 0xad, 0x44, 0xd9, 0xba,
+	0x90, 0x5f, 0xd9, 0xd9, 0xb9, 0x73, 0x3f, 0xc6, 0x6b, 0xfa, 0x91, 0x65, 0x2d, 0xb4, 0x70, 0xa3,
+	0x19, 0x89, 0xc3, 0x7d, 0xb0, 0xd8, 0x8f, 0xc4, 0x2c, 0x3b, 0x8c, 0x05, 0x69, 0xb8, 0xd2, 0x94,
+	0x12, 0xf7, 0xa3, 0xd1, 0xe8, 0xc7, 0xc7, 0x59, 0xa8, 0x22, 0x1d, 0xeb, 0x0a, 0x97, 0xe2, 0x83,
+	0x87, 0xe0, 0xff, 0x21, 0x3e, 0xbe, 0xc1, 0xf6, 0xf4, 0x9b, 0xd8, 0x7e, 0x47, 0xb1, 0xad, 0x2b,
+	0x89, 0xe1, 0xe3, 0x71, 0x44, 0x3f, 0x08, 0xf1, 0x71, 0x9e, 0x63, 0xeb, 0xef, 0x09, 0x50, 0x6a,
+	0xa5, 0xf7, 0x43, 0xa4, 0xc3, 0x62, 0xef, 0x3f, 0x76, 0xd8, 0x22, 0x28, 0xe6, 0xb7, 0x17, 0x52,
+	0x16, 0xdc, 0x06, 0xf3, 0x1e, 0xe5, 0x49, 0x4c, 0xdb, 0x7d, 0x31, 0x38, 0x15, 0x24, 0xd7, 0x94,
+	0x31, 0x1c, 0x98, 0x55, 0xa5, 0xbf, 0xdb, 0x41, 0x16, 0x82, 0x79, 0x54, 0x2d, 0xbc, 0x27, 0xa0,
+	0x18, 0x13, 0xcc, 0x99, 0x54, 0xd8, 0xec, 0xea, 0x43, 0xfb, 0xc6, 0x62, 0xb7, 0x1b, 0x51, 0x46,
+	0x13, 0x52, 0xa1, 0xf0, 0x29, 0x98, 0xc9, 0x30, 0x25, 0x04, 0xfb, 0x6e, 0x42, 0x40, 0xaf, 0xf2,
+	0x61, 0x05, 0xdc, 0xeb, 0xb1, 0xa3, 0x6c, 0x4b, 0x21, 0x69, 0xc0, 0x8f, 0xc1, 0x94, 0x90, 0xd9,
+	0xf4, 0x1b, 0x77, 0xe8, 0x4c, 0x7a, 0xb2, 0x58, 0x96, 0x22, 0xc3, 0xfa, 0x55, 0x03, 0xb3, 0x3b,
+	0x24, 0xc2, 0x41, 0x72, 0xd2, 0xea, 0x87, 0x21, 0x8e, 0x4f, 0xe0, 0xa7, 0x60, 0xf6, 0xda, 0x7b,
+	0xe4, 0x6a, 0x4f, 0x2d, 0x0f, 0x07, 0xe6, 0xc2, 0x98, 0xf7, 0xca, 0x2d, 0x74, 0x3f, 0xff, 0x46,
+	0xf9, 0xb5, 0xd5, 0xcb, 0x15, 0x25, 0x39, 0x04, 0x7e, 0x02, 0xee, 0x5f, 0xdb, 0xa9, 0x8a, 0x10,
+	0x7d, 0x38, 0x30, 0x2b, 0x63, 0x56, 0xae, 0x85, 0xca, 0xf9, 0x55, 0xfb, 0xfe, 0x2f, 0x1a, 0x00,
+	0xa3, 0x31, 0xc3, 0x0f, 0xc1, 0x62, 0xa3, 0xb9, 0x85, 0x3e, 0x5b, 0xdf, 0x6d, 0x6c, 0x37, 0xdd,
+	0xbd, 0x66, 0x6b, 0x67, 0x73, 0xbd, 0xb1, 0xd5, 0xd8, 0xdc, 0x98, 0x2b, 0x54, 0x97, 0xcf, 0x2e,
+	0x6a, 0x0b, 0xa3, 0xd8, 0xbd, 0x88, 0xf7, 0x48, 0x87, 0x76, 0x29, 0xf1, 0xe0, 0x07, 0xd7, 0xd2,
+	0x36, 0xb6, 0xf7, 0x9c, 0x2f, 0x37, 0xdd, 0x56, 0xe3, 0xf3, 0xe6, 0x9c, 0x56, 0xd5, 0xcf, 0x2e,
+	0x6a, 0x95, 0x51, 0x5a, 0xee, 0x91, 0xd7, 0xc1, 0xfc, 0xb5, 0xac, 0xfd, 0xe6, 0x6e, 0xe3, 0xab,
+	0xcd, 0xb9, 0x89, 0xea, 0xe2, 0xd9, 0x45, 0x0d, 0xe6, 0x53, 0xe4, 0x40, 0xaa, 0x53, 0xa7, 0x3f,
+	0x19, 0x05, 0xe7, 0xa3, 0x9f, 0x2f, 0x0d, 0xed, 0xc5, 0xa5, 0xa1, 0xbd, 0xbc, 0x34, 0xb4, 0xbf,
+	0x2e, 0x0d, 0xed, 0xfc, 0xca, 0x28, 0xbc, 0xbc, 0x32, 0x0a, 0xbf, 0x5f, 0x19, 0x85, 0x6f, 0x75,
+	0x9f, 0xe0, 0xc7, 0x3d, 0x86, 0xeb, 0xc7, 0xaf, 0xfe, 0x37, 0x48, 0xf2, 0xdb, 0x45, 0x41, 0xe1,
+	0x93, 0x7f, 0x07, 0x00, 0x1f, 0xda, 0x27, 0x65, 0x59, 0x08, 0x00, 0x00,
 }
 
 func (this *ValidatorSigningInfo) Equal(that interface{}) bool {
@@ -290,6 +527,90 @@ func (this *Params) Equal(that interface{}) bool {
 	if this.DowntimeJailDuration != that1.DowntimeJailDuration {
 		return false
 	}
+	if !this.SlashFractionDoubleSign.Equal(that1.SlashFractionDoubleSign) {
+		return false
+	}
+	if !this.SlashFractionDowntime.Equal(that1.SlashFractionDowntime) {
+		return false
+	}
+	if this.UnjailCooldownBlocks != that1.UnjailCooldownBlocks {
+		return false
+	}
+	if this.MaxJailDuration != that1.MaxJailDuration {
+		return false
+	}
+	return true
+}
+func (this *SlashRecord) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SlashRecord)
+	if !ok {
+		that2, ok := that.(SlashRecord)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Address != that1.Address {
+		return false
+	}
+	if this.Height != that1.Height {
+		return false
+	}
+	if this.DistributionHeight != that1.DistributionHeight {
+		return false
+	}
+	if this.Reason != that1.Reason {
+		return false
+	}
+	if !this.Fraction.Equal(that1.Fraction) {
+		return false
+	}
+	if this.Power != that1.Power {
+		return false
+	}
+	if !this.Time.Equal(that1.Time) {
+		return false
+	}
+	return true
+}
+func (this *PenaltySummary) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PenaltySummary)
+	if !ok {
+		that2, ok := that.(PenaltySummary)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.DowntimeJails != that1.DowntimeJails {
+		return false
+	}
+	if this.Tombstones != that1.Tombstones {
+		return false
+	}
+	if this.MissedBlocks != that1.MissedBlocks {
+		return false
+	}
 	return true
 }
 func (m *ValidatorSigningInfo) Marshal() (dAtA []byte, err error) {
@@ -375,13 +696,46 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
-	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.DowntimeJailDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.DowntimeJailDuration):])
+	n2, err2 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.MaxJailDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxJailDuration):])
 	if err2 != nil {
 		return 0, err2
 	}
 	i -= n2
 	i = encodeVarintSlashing(dAtA, i, uint64(n2))
 	i--
+	dAtA[i] = 0x3a
+	if m.UnjailCooldownBlocks != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.UnjailCooldownBlocks))
+		i--
+		dAtA[i] = 0x30
+	}
+	{
+		size := m.SlashFractionDowntime.Size()
+		i -= size
+		if _, err := m.SlashFractionDowntime.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSlashing(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	{
+		size := m.SlashFractionDoubleSign.Size()
+		i -= size
+		if _, err := m.SlashFractionDoubleSign.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSlashing(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	n3, err3 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.DowntimeJailDuration, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.DowntimeJailDuration):])
+	if err3 != nil {
+		return 0, err3
+	}
+	i -= n3
+	i = encodeVarintSlashing(dAtA, i, uint64(n3))
+	i--
 	dAtA[i] = 0x1a
 	{
 		size := m.MinSignedPerWindow.Size()
@@ -401,50 +755,156 @@ func (m *Params) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintSlashing(dAtA []byte, offset int, v uint64) int {
-	offset -= sovSlashing(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *SlashRecord) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ValidatorSigningInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *SlashRecord) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SlashRecord) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovSlashing(uint64(l))
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err4 != nil {
+		return 0, err4
 	}
-	if m.StartHeight != 0 {
-		n += 1 + sovSlashing(uint64(m.StartHeight))
+	i -= n4
+	i = encodeVarintSlashing(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x3a
+	if m.Power != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.Power))
+		i--
+		dAtA[i] = 0x30
 	}
-	if m.IndexOffset != 0 {
-		n += 1 + sovSlashing(uint64(m.IndexOffset))
+	{
+		size := m.Fraction.Size()
+		i -= size
+		if _, err := m.Fraction.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintSlashing(dAtA, i, uint64(size))
 	}
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.JailedUntil)
-	n += 1 + l + sovSlashing(uint64(l))
-	if m.Tombstoned {
-		n += 2
+	i--
+	dAtA[i] = 0x2a
+	if m.Reason != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.Reason))
+		i--
+		dAtA[i] = 0x20
 	}
-	if m.MissedBlocksCounter != 0 {
-		n += 1 + sovSlashing(uint64(m.MissedBlocksCounter))
+	if m.DistributionHeight != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.DistributionHeight))
+		i--
+		dAtA[i] = 0x18
 	}
-	return n
-}
-
-func (m *Params) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.Height != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
 	}
-	var l int
-	_ = l
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintSlashing(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PenaltySummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PenaltySummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PenaltySummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MissedBlocks != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.MissedBlocks))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Tombstones != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.Tombstones))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.DowntimeJails != 0 {
+		i = encodeVarintSlashing(dAtA, i, uint64(m.DowntimeJails))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintSlashing(dAtA []byte, offset int, v uint64) int {
+	offset -= sovSlashing(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ValidatorSigningInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSlashing(uint64(l))
+	}
+	if m.StartHeight != 0 {
+		n += 1 + sovSlashing(uint64(m.StartHeight))
+	}
+	if m.IndexOffset != 0 {
+		n += 1 + sovSlashing(uint64(m.IndexOffset))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.JailedUntil)
+	n += 1 + l + sovSlashing(uint64(l))
+	if m.Tombstoned {
+		n += 2
+	}
+	if m.MissedBlocksCounter != 0 {
+		n += 1 + sovSlashing(uint64(m.MissedBlocksCounter))
+	}
+	return n
+}
+
+func (m *Params) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
 	if m.SignedBlocksWindow != 0 {
 		n += 1 + sovSlashing(uint64(m.SignedBlocksWindow))
 	}
@@ -452,6 +912,62 @@ func (m *Params) Size() (n int) {
 	n += 1 + l + sovSlashing(uint64(l))
 	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.DowntimeJailDuration)
 	n += 1 + l + sovSlashing(uint64(l))
+	l = m.SlashFractionDoubleSign.Size()
+	n += 1 + l + sovSlashing(uint64(l))
+	l = m.SlashFractionDowntime.Size()
+	n += 1 + l + sovSlashing(uint64(l))
+	if m.UnjailCooldownBlocks != 0 {
+		n += 1 + sovSlashing(uint64(m.UnjailCooldownBlocks))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.MaxJailDuration)
+	n += 1 + l + sovSlashing(uint64(l))
+	return n
+}
+
+func (m *SlashRecord) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovSlashing(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovSlashing(uint64(m.Height))
+	}
+	if m.DistributionHeight != 0 {
+		n += 1 + sovSlashing(uint64(m.DistributionHeight))
+	}
+	if m.Reason != 0 {
+		n += 1 + sovSlashing(uint64(m.Reason))
+	}
+	l = m.Fraction.Size()
+	n += 1 + l + sovSlashing(uint64(l))
+	if m.Power != 0 {
+		n += 1 + sovSlashing(uint64(m.Power))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovSlashing(uint64(l))
+	return n
+}
+
+func (m *PenaltySummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DowntimeJails != 0 {
+		n += 1 + sovSlashing(uint64(m.DowntimeJails))
+	}
+	if m.Tombstones != 0 {
+		n += 1 + sovSlashing(uint64(m.Tombstones))
+	}
+	if m.MissedBlocks != 0 {
+		n += 1 + sovSlashing(uint64(m.MissedBlocks))
+	}
 	return n
 }
 
@@ -767,6 +1283,455 @@ func (m *Params) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionDoubleSign", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashFractionDoubleSign.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SlashFractionDowntime", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SlashFractionDowntime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UnjailCooldownBlocks", wireType)
+			}
+			m.UnjailCooldownBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UnjailCooldownBlocks |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxJailDuration", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.MaxJailDuration, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSlashing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SlashRecord) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSlashing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SlashRecord: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SlashRecord: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Address", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Address = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DistributionHeight", wireType)
+			}
+			m.DistributionHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DistributionHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reason", wireType)
+			}
+			m.Reason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Reason |= Infraction(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fraction", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Fraction.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Power", wireType)
+			}
+			m.Power = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Power |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSlashing(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthSlashing
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PenaltySummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSlashing
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PenaltySummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PenaltySummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DowntimeJails", wireType)
+			}
+			m.DowntimeJails = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DowntimeJails |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tombstones", wireType)
+			}
+			m.Tombstones = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Tombstones |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MissedBlocks", wireType)
+			}
+			m.MissedBlocks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSlashing
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MissedBlocks |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSlashing(dAtA[iNdEx:])